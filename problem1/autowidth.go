@@ -0,0 +1,148 @@
+package problem1
+
+import (
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// MaxNumberWidth returns the width, in characters, of the widest whole number
+// in inputs, or 0 if there is none. Fractions are not counted since they are
+// never padded.
+func MaxNumberWidth(inputs []string) int {
+	return widest(ColumnWidths(inputs))
+}
+
+// ColumnWidths returns the width of the widest whole number at every position
+// of a line: the n-th value is the widest n-th number over all lines of all
+// inputs.
+func ColumnWidths(inputs []string) []int {
+	var m widthMeter
+	for _, input := range inputs {
+		// Each input starts a new line
+		m.p = padder{}
+		m.measure([]byte(input), true)
+	}
+	return m.widths
+}
+
+// PadNumbersAuto pads the whole numbers in every input to the width of the
+// widest number in the whole batch.
+func PadNumbersAuto(inputs []string) []string {
+	width := MaxNumberWidth(inputs)
+
+	out := make([]string, len(inputs))
+	for i, input := range inputs {
+		out[i] = PadNumbers(input, width)
+	}
+	return out
+}
+
+// PadNumbersByColumn pads the n-th whole number on each line to the width of
+// the widest n-th number in the batch, so numbers line up by position.
+func PadNumbersByColumn(inputs []string) []string {
+	widths := ColumnWidths(inputs)
+
+	out := make([]string, len(inputs))
+	for i, input := range inputs {
+		p := padder{columns: widths}
+		padded, _, _ := p.pad(make([]byte, 0, len(input)), []byte(input), true)
+		out[i] = string(padded)
+	}
+	return out
+}
+
+// PadStreamAuto copies r to w, padding every whole number to the width of the
+// widest one in r, or to the widest one at the same position of a line when
+// perColumn is set. opts controls everything but the width, which is
+// ignored. The input is read once to measure it while being spooled to a
+// temporary file, which is then padded in a second pass, so memory use does
+// not depend on the input size.
+func PadStreamAuto(w io.Writer, r io.Reader, opts Options, perColumn bool) error {
+	tmp, err := os.CreateTemp("", "padder-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	m := widthMeter{p: padder{opts: opts}}
+	if _, err := io.Copy(io.MultiWriter(tmp, &m), r); err != nil {
+		return err
+	}
+	m.Close()
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	opts.Width = widest(m.widths)
+	pr := NewPadReaderWithOptions(tmp, opts)
+	if perColumn {
+		pr.p.columns = m.widths
+	}
+
+	_, err = io.Copy(w, pr)
+	return err
+}
+
+// widthMeter records the widest whole number per line position in everything
+// written to it.
+type widthMeter struct {
+	p       padder
+	pending []byte
+	widths  []int
+}
+
+func (m *widthMeter) Write(b []byte) (int, error) {
+	m.pending = append(m.pending, b...)
+	n := m.measure(m.pending, false)
+	m.pending = m.pending[:copy(m.pending, m.pending[n:])]
+	return len(b), nil
+}
+
+// Close measures any buffered input.
+func (m *widthMeter) Close() error {
+	m.measure(m.pending, true)
+	m.pending = m.pending[:0]
+	return nil
+}
+
+// measure scans src and returns the number of bytes consumed, following the
+// same chunking rules as padder.pad.
+func (m *widthMeter) measure(src []byte, final bool) int {
+	i := 0
+	for i < len(src) {
+		tok, n, ok := m.p.next(src[i:], final)
+		if !ok {
+			break
+		}
+		i += n
+
+		var width int
+		switch tok.kind {
+		case tokenWhole:
+			width = utf8.RuneCount(tok.text)
+			if tok.sign != 0 {
+				width++
+			}
+		case tokenLiteral:
+			width = m.p.opts.literalWidth(tok)
+		default:
+			continue
+		}
+		for len(m.widths) <= tok.column {
+			m.widths = append(m.widths, 0)
+		}
+		m.widths[tok.column] = max(m.widths[tok.column], width)
+	}
+	return i
+}
+
+func widest(widths []int) int {
+	w := 0
+	for _, width := range widths {
+		w = max(w, width)
+	}
+	return w
+}
@@ -0,0 +1,98 @@
+package problem1
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestMaxNumberWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   []string
+		expected int
+	}{
+		{"No inputs", nil, 0},
+		{"No numbers", []string{"abc", ""}, 0},
+		{"Widest across inputs", []string{"img1.png", "img250.png", "img17.png"}, 3},
+		{"Fractions not counted", []string{"3.14159"}, 1},
+		{"Unicode digits", []string{"第１２話"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaxNumberWidth(tt.inputs); got != tt.expected {
+				t.Errorf("MaxNumberWidth(%q) = %d; want %d", tt.inputs, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	inputs := []string{"1 200 3", "40 5\n6 7 800 9"}
+	want := []int{2, 3, 3, 1}
+
+	if got := ColumnWidths(inputs); !slices.Equal(got, want) {
+		t.Errorf("ColumnWidths(%q) = %v; want %v", inputs, got, want)
+	}
+}
+
+func TestPadNumbersAuto(t *testing.T) {
+	inputs := []string{"img1.png", "img250.png", "img17.png"}
+	want := []string{"img001.png", "img250.png", "img017.png"}
+
+	if got := PadNumbersAuto(inputs); !slices.Equal(got, want) {
+		t.Errorf("PadNumbersAuto(%q) = %q; want %q", inputs, got, want)
+	}
+}
+
+func TestPadNumbersByColumn(t *testing.T) {
+	inputs := []string{"row 1: 5 of 100", "row 12: 40 of 7"}
+	want := []string{"row 01: 05 of 100", "row 12: 40 of 007"}
+
+	if got := PadNumbersByColumn(inputs); !slices.Equal(got, want) {
+		t.Errorf("PadNumbersByColumn(%q) = %q; want %q", inputs, got, want)
+	}
+}
+
+func TestPadStreamAuto(t *testing.T) {
+	input := "row 1: 5 of 100\nrow 12: 40 of 7\n"
+
+	tests := []struct {
+		name      string
+		perColumn bool
+		expected  string
+	}{
+		{"Widest overall", false, "row 001: 005 of 100\nrow 012: 040 of 007\n"},
+		{"Per column", true, "row 01: 05 of 100\nrow 12: 40 of 007\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			r := iotest.OneByteReader(strings.NewReader(input))
+			if err := PadStreamAuto(&out, r, Options{}, tt.perColumn); err != nil {
+				t.Fatalf("PadStreamAuto() error: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("PadStreamAuto(%q, %v) = %q; want %q", input, tt.perColumn, out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadStreamAutoNullSeparated(t *testing.T) {
+	input := "a1\x00a200\x00b3\nc40"
+	want := "a001\x00a200\x00b003\nc40" // "\n" does not end a line here
+
+	var out bytes.Buffer
+	err := PadStreamAuto(&out, strings.NewReader(input), Options{NullSeparated: true}, true)
+	if err != nil {
+		t.Fatalf("PadStreamAuto() error: %v", err)
+	}
+	if out.String() != want {
+		t.Errorf("PadStreamAuto(%q) = %q; want %q", input, out.String(), want)
+	}
+}
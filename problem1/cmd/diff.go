@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"deficheck/problem1"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffFile writes a unified diff between the named file (or stdin) and its
+// padded form to w. It reports whether there was any difference.
+func diffFile(w io.Writer, name string, cfg config) (bool, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	input := string(data)
+	output, spans, err := problem1.PadNumbersReport(input, cfg.opts)
+	if err != nil {
+		return false, err
+	}
+	return writeDiff(w, name, input, output, spans)
+}
+
+// writeDiff writes input and output as a unified diff, with the lines that
+// contain a changed span as the changed lines. Padding never adds or removes
+// line breaks, so the n-th output line is the padded n-th input line.
+func writeDiff(w io.Writer, name, input, output string, spans []problem1.Span) (bool, error) {
+	oldLines := splitLines(input)
+	newLines := splitLines(output)
+
+	var changed []int // indexes of the changed lines, ascending
+	line, lineStart := 0, 0
+	for _, s := range spans {
+		if !s.Changed {
+			continue
+		}
+		for lineStart+len(oldLines[line]) <= s.Offset {
+			lineStart += len(oldLines[line])
+			line++
+		}
+		if len(changed) == 0 || changed[len(changed)-1] != line {
+			changed = append(changed, line)
+		}
+	}
+	if len(changed) == 0 {
+		return false, nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", name, name)
+	for len(changed) > 0 {
+		// A hunk takes every change that is at most twice the context
+		// away from the last one, so that hunks never overlap or touch.
+		n := 1
+		for n < len(changed) && changed[n]-changed[n-1]-1 <= 2*diffContext {
+			n++
+		}
+		start := max(changed[0]-diffContext, 0)
+		end := min(changed[n-1]+diffContext+1, len(oldLines))
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(start, end), hunkRange(start, end))
+		// Runs of changed lines are shown as all removals, then all
+		// additions.
+		for k := start; k < end; {
+			if !slices.Contains(changed[:n], k) {
+				writeDiffLine(&b, ' ', oldLines[k])
+				k++
+				continue
+			}
+			run := k
+			for run < end && slices.Contains(changed[:n], run) {
+				run++
+			}
+			for _, l := range oldLines[k:run] {
+				writeDiffLine(&b, '-', l)
+			}
+			for _, l := range newLines[k:run] {
+				writeDiffLine(&b, '+', l)
+			}
+			k = run
+		}
+		changed = changed[n:]
+	}
+
+	_, err := w.Write(b.Bytes())
+	return true, err
+}
+
+// splitLines splits s after every '\n'. The last line has no line break if s
+// does not end with one.
+func splitLines(s string) []string {
+	var lines []string
+	for len(s) > 0 {
+		n := len(s)
+		if k := strings.IndexByte(s, '\n'); k >= 0 {
+			n = k + 1
+		}
+		lines = append(lines, s[:n])
+		s = s[n:]
+	}
+	return lines
+}
+
+// hunkRange formats the lines [start, end) as "first,count", counting from 1.
+func hunkRange(start, end int) string {
+	if end-start == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, end-start)
+}
+
+func writeDiffLine(b *bytes.Buffer, prefix byte, line string) {
+	b.WriteByte(prefix)
+	b.WriteString(line)
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"deficheck/problem1"
+)
+
+func TestDiffFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		changed bool
+		want    string
+	}{
+		{"No change", "a 007\nb 100.5\n", false, ""},
+		{"Single line", "x7", true, "--- f\n+++ f\n@@ -1 +1 @@\n-x7\n\\ No newline at end of file\n+x007\n\\ No newline at end of file\n"},
+		{"Context and runs", "1\nab\ncd\nef\n2 3\n4\nxy\n", true,
+			"--- f\n+++ f\n@@ -1,7 +1,7 @@\n-1\n+001\n ab\n cd\n ef\n-2 3\n-4\n+002 003\n+004\n xy\n"},
+		{"Touching hunks merge", "1\na\nb\nc\nd\ne\nf\n2\n", true,
+			"--- f\n+++ f\n@@ -1,8 +1,8 @@\n-1\n+001\n a\n b\n c\n d\n e\n f\n-2\n+002\n"},
+		{"Separate hunks", "1\na\nb\nc\nd\ne\nf\ng\nh\n2\n", true,
+			"--- f\n+++ f\n@@ -1,4 +1,4 @@\n-1\n+001\n a\n b\n c\n@@ -7,4 +7,4 @@\n f\n g\n h\n-2\n+002\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "f")
+			os.WriteFile(name, []byte(tt.input), 0o644)
+
+			var out strings.Builder
+			changed, err := diffFile(&out, name, config{opts: problem1.Options{Width: 3}})
+			if err != nil {
+				t.Fatalf("diffFile() error: %v", err)
+			}
+			got := strings.ReplaceAll(out.String(), name, "f")
+			if changed != tt.changed || got != tt.want {
+				t.Errorf("diffFile(%q) = %v,\n%s\nwant %v,\n%s", tt.input, changed, got, tt.changed, tt.want)
+			}
+		})
+	}
+}
@@ -1,29 +1,513 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 
 	"deficheck/problem1"
 )
 
+// Exit codes
+const (
+	exitOK      = 0
+	exitChanged = 1 // -check or -diff found input that would change
+	exitUsage   = 2 // invalid arguments or a refused request, same code the flag package uses
+	exitIO      = 3 // reading or writing a file failed
+)
+
+// usageError marks errors caused by invalid command-line arguments, and
+// requests that are refused before anything is changed.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// config holds the options shared by every input of a run.
+type config struct {
+	opts    problem1.Options
+	auto    bool // width from the widest number in each input
+	rules   []problem1.Rule
+	columns bool
+	inPlace bool
+	backup  string
+	check   bool
+	diff    bool
+	null    bool
+	format  string // "", "csv" or "json"
+	selects []string
+	header  bool
+}
+
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Println("Usage: go run main.go \"<input string>\" <width>")
-		fmt.Println("Example: go run main.go \"James Bond 7\" 3")
-		os.Exit(1)
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "sort":
+		err = runSort(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "rename":
+		err = runRename(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "serve":
+		err = runServe(os.Args[2:])
+	default:
+		os.Exit(run(os.Args[1:]))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+}
+
+func exitCode(err error) int {
+	var usage usageError
+	if errors.As(err, &usage) {
+		return exitUsage
+	}
+	return exitIO
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("padder", flag.ContinueOnError)
+	var widthArg string
+	flags.StringVar(&widthArg, "w", "", "Pad width, or 'auto' to use the widest number in each input")
+	flags.StringVar(&widthArg, "width", "", "Same as -w")
+	columns := flags.Bool("columns", false, "With width 'auto', align the n-th number of each line to the widest n-th number")
+	var rules []problem1.Rule
+	flags.Func("rule", "Only pad the numbers a rule selects: `PATTERN=WIDTH` for the first capture group of a regexp, or #N=WIDTH for the N-th number of each line; repeatable, the first matching rule wins", func(s string) error {
+		rule, err := problem1.ParseRule(s)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+		return nil
+	})
+	literals := flags.Bool("literals", false, "Recognise hex/octal/binary literals, scientific notation, versions, IPv4 addresses and ordinals")
+	null := flags.Bool("null", false, "Lines are separated by NUL bytes instead of newlines; -check also prints file names NUL-terminated")
+	var inPlace bool
+	flags.BoolVar(&inPlace, "i", false, "Edit files in place instead of writing to stdout")
+	flags.BoolVar(&inPlace, "in-place", false, "Same as -i")
+	backup := flags.String("backup", "", "With -i, keep each original file with this suffix appended (e.g. .bak)")
+	check := flags.Bool("check", false, "Write nothing; list the inputs that would change and exit with status 1 if there are any")
+	diff := flags.Bool("diff", false, "Write a unified diff of the changes instead of the padded output; exit with status 1 if there are any")
+	format := flags.String("format", "", "Keep the structure of a `csv` or json input and only pad the values chosen with -select")
+	var selects []string
+	flags.Func("select", "With -format, a value to pad: a column name or #N for csv, a JSON pointer such as /items/*/id for json; repeatable, everything is padded if absent", func(s string) error {
+		selects = append(selects, s)
+		return nil
+	})
+	header := flags.Bool("header", false, "With -format csv, the first record is a header and is not padded")
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintln(out, "Usage: padder -w <width|auto> [options] [file ...]")
+		fmt.Fprintln(out, "       padder \"<input string>\" <width|auto>")
+		fmt.Fprintln(out, "       padder sort [-null] < lines.txt")
+		fmt.Fprintln(out, "       padder rename [options] [dir]")
+		fmt.Fprintln(out, "       padder serve [-addr host:port]")
+		fmt.Fprintln(out, "Example: padder \"James Bond 7\" 3")
+		fmt.Fprintln(out, "         padder -w 6 -i -backup .bak access.log")
+		fmt.Fprintln(out, "         ls | padder -rule 'E(\\d+)=3'")
+		fmt.Fprintln(out, "         padder -w 4 -format csv -select id data.csv")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Reads stdin when no file (or \"-\") is given.")
+		fmt.Fprintln(out, "Exit status: 0 ok, 1 -check or -diff found changes, 2 usage error or refusal, 3 I/O error.")
+		fmt.Fprintln(out)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	files := flags.Args()
+
+	fail := func(err error) int {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCode(err)
+	}
+
+	if widthArg == "" && len(rules) == 0 {
+		if len(files) != 2 || inPlace || *check || *diff {
+			flags.Usage()
+			return exitUsage
+		}
+		if err := demo(files[0], files[1]); err != nil {
+			return fail(err)
+		}
+		return exitOK
+	}
+
+	// With rules and no width, only the selected numbers are padded
+	var width int
+	var auto bool
+	if widthArg != "" {
+		var err error
+		width, auto, err = parseWidth(widthArg)
+		if err != nil {
+			return fail(err)
+		}
+	}
+	cfg := config{
+		opts:    problem1.Options{Width: width, NullSeparated: *null},
+		auto:    auto,
+		rules:   rules,
+		columns: *columns,
+		inPlace: inPlace,
+		backup:  *backup,
+		check:   *check,
+		diff:    *diff,
+		null:    *null,
+		format:  *format,
+		selects: selects,
+		header:  *header,
+	}
+	if *literals {
+		cfg.opts.Literals = problem1.DefaultLiterals
+	}
+
+	if len(files) == 0 {
+		files = []string{"-"}
 	}
+	switch {
+	case cfg.auto && len(cfg.rules) > 0:
+		return fail(usageError{"-rule cannot be combined with width auto"})
+	case cfg.inPlace && cfg.check:
+		return fail(usageError{"-i and -check cannot be combined"})
+	case cfg.diff && (cfg.inPlace || cfg.check):
+		return fail(usageError{"-diff cannot be combined with -i or -check"})
+	case cfg.diff && (cfg.auto || len(cfg.rules) > 0 || cfg.format != ""):
+		return fail(usageError{"-diff cannot be combined with width auto, -rule or -format"})
+	case cfg.backup != "" && !cfg.inPlace:
+		return fail(usageError{"-backup requires -i"})
+	case cfg.inPlace && slices.Contains(files, "-"):
+		return fail(usageError{"cannot edit stdin in place"})
+	case cfg.format != "" && cfg.format != "csv" && cfg.format != "json":
+		return fail(usageError{fmt.Sprintf("unknown format %q, want csv or json", cfg.format)})
+	case cfg.format != "" && (cfg.auto || len(cfg.rules) > 0):
+		return fail(usageError{"-format cannot be combined with width auto or -rule"})
+	case cfg.format == "" && (len(cfg.selects) > 0 || cfg.header):
+		return fail(usageError{"-select and -header require -format"})
+	case cfg.header && cfg.format != "csv":
+		return fail(usageError{"-header requires -format csv"})
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	status := exitOK
+	for _, name := range files {
+		var err error
+		switch {
+		case cfg.check:
+			var changed bool
+			changed, err = checkFile(name, cfg)
+			if changed {
+				out.WriteString(name)
+				out.WriteByte(cfg.lineEnd())
+				if status == exitOK {
+					status = exitChanged
+				}
+			}
+		case cfg.diff:
+			var changed bool
+			changed, err = diffFile(out, name, cfg)
+			if changed && status == exitOK {
+				status = exitChanged
+			}
+		case cfg.inPlace:
+			err = padInPlace(name, cfg)
+		default:
+			err = padFile(out, name, cfg)
+		}
+
+		if err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			status = exitIO
+		}
+	}
+	if err := out.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		status = exitIO
+	}
+	return status
+}
 
-	input := os.Args[1]
-	width, err := strconv.Atoi(os.Args[2])
+func (c config) lineEnd() byte {
+	if c.null {
+		return 0
+	}
+	return '\n'
+}
+
+// demo pads a single string given on the command line and prints a report.
+func demo(input, widthArg string) error {
+	width, auto, err := parseWidth(widthArg)
 	if err != nil {
-		fmt.Printf("Error: Invalid width parameter: %s\n", os.Args[2])
-		os.Exit(1)
+		return err
 	}
 
-	result := problem1.PadNumbers(input, width)
+	var result string
+	if auto {
+		width = problem1.MaxNumberWidth([]string{input})
+		result = problem1.PadNumbersAuto([]string{input})[0]
+	} else {
+		result = problem1.PadNumbers(input, width)
+	}
 	fmt.Printf("Input:  %s\n", input)
 	fmt.Printf("Width:  %d\n", width)
 	fmt.Printf("Output: %s\n", result)
+	return nil
+}
+
+// parseWidth parses the width argument, which is either a number or "auto".
+func parseWidth(arg string) (width int, auto bool, err error) {
+	if arg == "auto" {
+		return 0, true, nil
+	}
+	width, err = strconv.Atoi(arg)
+	if err != nil {
+		return 0, false, usageError{fmt.Sprintf("invalid width parameter: %s", arg)}
+	}
+	return width, false, nil
+}
+
+// openInput opens the named file, or stdin for "-".
+func openInput(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(name)
+}
+
+// padStream pads r into w. Without auto it streams through a PadReader so
+// that inputs larger than memory can be processed; with auto the input is
+// measured first, see problem1.PadStreamAuto.
+func padStream(w io.Writer, r io.Reader, cfg config) error {
+	switch cfg.format {
+	case "csv":
+		return problem1.PadCSV(w, r, problem1.CSVOptions{Options: cfg.opts, Columns: cfg.selects, Header: cfg.header})
+	case "json":
+		return problem1.PadJSON(w, r, cfg.selects, cfg.opts)
+	}
+	if len(cfg.rules) > 0 {
+		return padLines(w, r, cfg)
+	}
+	if cfg.auto {
+		return problem1.PadStreamAuto(w, r, cfg.opts, cfg.columns)
+	}
+	_, err := io.Copy(w, problem1.NewPadReaderWithOptions(r, cfg.opts))
+	return err
+}
+
+// padLines pads r into w one line at a time, as rules need the whole line to
+// decide which numbers to pad.
+func padLines(w io.Writer, r io.Reader, cfg config) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString(cfg.lineEnd())
+		if line != "" {
+			padded, padErr := problem1.PadNumbersByRules(line, cfg.rules, cfg.opts)
+			if padErr != nil {
+				return padErr
+			}
+			if _, err := io.WriteString(w, padded); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// padFile writes the padded content of the named file (or stdin) to w.
+func padFile(w io.Writer, name string, cfg config) error {
+	in, err := openInput(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return padStream(w, in, cfg)
+}
+
+// padInPlace replaces the named file with its padded content. The result is
+// written to a temporary file in the same directory first and then renamed
+// over the original, so the file is never left half written.
+func padInPlace(name string, cfg config) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	out := bufio.NewWriter(tmp)
+	if err := padStream(out, in, cfg); err != nil {
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if cfg.backup != "" {
+		if err := os.Rename(name, name+cfg.backup); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// checkFile reports whether padding would change the named file (or stdin).
+func checkFile(name string, cfg config) (bool, error) {
+	var orig, src io.Reader
+	if name == "-" {
+		// stdin can only be read once
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return false, err
+		}
+		orig, src = bytes.NewReader(data), bytes.NewReader(data)
+	} else {
+		f1, err := os.Open(name)
+		if err != nil {
+			return false, err
+		}
+		defer f1.Close()
+		f2, err := os.Open(name)
+		if err != nil {
+			return false, err
+		}
+		defer f2.Close()
+		orig, src = f1, f2
+	}
+
+	d := diffWriter{orig: bufio.NewReader(orig)}
+	if err := padStream(&d, src, cfg); err != nil {
+		return false, err
+	}
+	return d.finish()
+}
+
+// diffWriter compares everything written to it with the content of orig.
+type diffWriter struct {
+	orig    io.Reader
+	buf     []byte
+	differs bool
+	err     error
+}
+
+func (d *diffWriter) Write(p []byte) (int, error) {
+	if d.differs || d.err != nil {
+		return len(p), nil
+	}
+
+	if cap(d.buf) < len(p) {
+		d.buf = make([]byte, len(p))
+	}
+	n, err := io.ReadFull(d.orig, d.buf[:len(p)])
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		d.err = err
+	}
+	if n < len(p) || !bytes.Equal(d.buf[:n], p) {
+		d.differs = true
+	}
+	return len(p), nil
+}
+
+// finish reports whether the written content differed from orig, including
+// orig being longer.
+func (d *diffWriter) finish() (bool, error) {
+	if d.err != nil || d.differs {
+		return d.differs, d.err
+	}
+	var b [1]byte
+	n, err := d.orig.Read(b[:])
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// runSort implements the sort subcommand.
+func runSort(args []string) error {
+	flags := flag.NewFlagSet("sort", flag.ExitOnError)
+	null := flags.Bool("null", false, "Lines are separated by NUL bytes instead of newlines")
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		return usageError{"sort reads from stdin and takes no arguments"}
+	}
+
+	sep := byte('\n')
+	if *null {
+		sep = 0
+	}
+	return sortLines(os.Stdin, os.Stdout, sep)
+}
+
+// sortLines writes the lines of r, separated by sep, to w in natural order
+// ("file2" before "file10").
+func sortLines(r io.Reader, w io.Writer, sep byte) error {
+	type line struct {
+		text string
+		key  string
+	}
+
+	var lines []line
+	br := bufio.NewReader(r)
+	for {
+		text, err := br.ReadString(sep)
+		if text != "" {
+			text = strings.TrimSuffix(text, string(sep))
+			lines = append(lines, line{text: text, key: problem1.NaturalSortKey(text)})
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	slices.SortFunc(lines, func(a, b line) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	out := bufio.NewWriter(w)
+	for _, l := range lines {
+		out.WriteString(l.text)
+		out.WriteByte(sep)
+	}
+	return out.Flush()
 }
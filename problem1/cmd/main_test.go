@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPadInPlace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.txt")
+	if err := os.WriteFile(path, []byte("line 7\nline 42\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config{auto: true, inPlace: true, backup: ".bak"}
+	if err := padInPlace(path, cfg); err != nil {
+		t.Fatalf("padInPlace() error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "line 07\nline 42\n"; string(got) != want {
+		t.Errorf("padded file = %q; want %q", got, want)
+	}
+	backup, err := os.ReadFile(path + ".bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "line 7\nline 42\n"; string(backup) != want {
+		t.Errorf("backup = %q; want %q", backup, want)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v; want %v", info.Mode().Perm(), os.FileMode(0o600))
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		changed bool
+	}{
+		{"Already padded", "a007 b123", false},
+		{"Needs padding", "a7 b123", true},
+		{"Padding shortens nothing", "a1234", false},
+		{"Empty file", "", false},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "input.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg := config{}
+			cfg.opts.Width = 3
+			changed, err := checkFile(path, cfg)
+			if err != nil {
+				t.Fatalf("checkFile() error: %v", err)
+			}
+			if changed != tt.changed {
+				t.Errorf("checkFile(%q) = %v; want %v", tt.content, changed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	padded := filepath.Join(dir, "padded.txt")
+	unpadded := filepath.Join(dir, "unpadded.txt")
+	os.WriteFile(padded, []byte("x007"), 0o644)
+	os.WriteFile(unpadded, []byte("x7"), 0o644)
+	csvFile := filepath.Join(dir, "data.csv")
+	jsonFile := filepath.Join(dir, "data.json")
+	os.WriteFile(csvFile, []byte("id,n\n7,005\n"), 0o644)
+	os.WriteFile(jsonFile, []byte(`{"id": 7, "n": "005"}`), 0o644)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"Check clean", []string{"-w", "3", "-check", padded}, exitOK},
+		{"Check dirty", []string{"-width", "3", "-check", padded, unpadded}, exitChanged},
+		{"Bad width", []string{"-w", "x", padded}, exitUsage},
+		{"In-place with check", []string{"-w", "3", "-i", "-check", padded}, exitUsage},
+		{"Backup without in-place", []string{"-w", "3", "-backup", ".bak", padded}, exitUsage},
+		{"In-place on stdin", []string{"-w", "3", "-i", "-"}, exitUsage},
+		{"Diff clean", []string{"-w", "3", "-diff", padded}, exitOK},
+		{"Diff dirty", []string{"-w", "3", "-diff", padded, unpadded}, exitChanged},
+		{"Diff with check", []string{"-w", "3", "-diff", "-check", padded}, exitUsage},
+		{"Diff with auto", []string{"-w", "auto", "-diff", padded}, exitUsage},
+		{"Rule check", []string{"-rule", `x(\d+)=3`, "-check", padded}, exitOK},
+		{"Bad rule", []string{"-rule", "x(=3", padded}, exitUsage},
+		{"Rule with auto", []string{"-w", "auto", "-rule", "#1=3", padded}, exitUsage},
+		{"CSV column check", []string{"-w", "3", "-format", "csv", "-select", "n", "-check", csvFile}, exitOK},
+		{"CSV all columns check", []string{"-w", "3", "-format", "csv", "-header", "-check", csvFile}, exitChanged},
+		{"CSV unknown column", []string{"-w", "3", "-format", "csv", "-select", "x", csvFile}, exitIO},
+		{"JSON check", []string{"-w", "3", "-format", "json", "-select", "/n", "-check", jsonFile}, exitOK},
+		{"JSON invalid", []string{"-w", "3", "-format", "json", csvFile}, exitIO},
+		{"Unknown format", []string{"-w", "3", "-format", "xml", padded}, exitUsage},
+		{"Format with auto", []string{"-w", "auto", "-format", "csv", padded}, exitUsage},
+		{"Select without format", []string{"-w", "3", "-select", "id", padded}, exitUsage},
+		{"Header with json", []string{"-w", "3", "-format", "json", "-header", jsonFile}, exitUsage},
+		{"Missing file", []string{"-w", "3", "-check", filepath.Join(dir, "missing.txt")}, exitIO},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run(tt.args); got != tt.want {
+				t.Errorf("run(%q) = %d; want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"deficheck/problem1"
+)
+
+// renameOp moves one file. Paths are absolute so that a journal can be
+// replayed from any working directory.
+type renameOp struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// renameJournal is the undo record, updated before every rename.
+type renameJournal struct {
+	Renames []renameOp `json:"renames"`
+}
+
+// renameConfig selects which files are renamed and how.
+type renameConfig struct {
+	width      int
+	auto       bool // width from the widest number per directory
+	include    string
+	exclude    string
+	extensions bool // also pad numbers in file extensions
+}
+
+// runRename implements the rename subcommand.
+func runRename(args []string) error {
+	flags := flag.NewFlagSet("rename", flag.ContinueOnError)
+	width := flags.String("width", "auto", "Pad width, or 'auto' to use the widest number among the files of each directory")
+	dryRun := flags.Bool("dry-run", false, "Print the renames without performing them")
+	include := flags.String("include", "*", "Only rename files whose name matches this glob")
+	exclude := flags.String("exclude", "", "Skip files whose name matches this glob")
+	extensions := flags.Bool("ext", false, "Also pad numbers in file extensions (e.g. .mp4)")
+	journal := flags.String("journal", "rename-journal.json", "Where to write the undo journal")
+	undo := flags.String("undo", "", "Revert the renames recorded in this journal")
+	flags.Usage = func() {
+		fmt.Println("Usage: padder rename [options] [dir]")
+		fmt.Println("       padder rename -undo <journal.json>")
+		fmt.Println()
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return usageError{err.Error()}
+	}
+
+	if *undo != "" {
+		if flags.NArg() != 0 {
+			flags.Usage()
+			return usageError{"-undo takes no directory"}
+		}
+		return undoRenames(*undo, *dryRun)
+	}
+
+	root := "."
+	switch flags.NArg() {
+	case 0:
+	case 1:
+		root = flags.Arg(0)
+	default:
+		flags.Usage()
+		return usageError{"rename takes at most one directory"}
+	}
+
+	if _, err := filepath.Match(*include, ""); err != nil {
+		return usageError{fmt.Sprintf("invalid include pattern: %v", err)}
+	}
+	if _, err := filepath.Match(*exclude, ""); err != nil {
+		return usageError{fmt.Sprintf("invalid exclude pattern: %v", err)}
+	}
+
+	cfg := renameConfig{include: *include, exclude: *exclude, extensions: *extensions}
+	var err error
+	cfg.width, cfg.auto, err = parseWidth(*width)
+	if err != nil {
+		return err
+	}
+
+	ops, err := planRenames(root, cfg)
+	if err != nil {
+		return err
+	}
+	return performRenames(ops, *dryRun, *journal)
+}
+
+// planRenames walks the tree under root and returns the renames needed to
+// pad the numbers in the names of the selected files. Directories are never
+// renamed.
+func planRenames(root string, cfg renameConfig) ([]renameOp, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make(map[string][]string)
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if ok, _ := filepath.Match(cfg.include, name); !ok {
+			return nil
+		}
+		if ok, _ := filepath.Match(cfg.exclude, name); ok {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		dirs[dir] = append(dirs[dir], name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []renameOp
+	for dir, names := range dirs {
+		stems := make([]string, len(names))
+		exts := make([]string, len(names))
+		for i, name := range names {
+			stems[i], exts[i] = splitExt(name, cfg.extensions)
+		}
+
+		width := cfg.width
+		if cfg.auto {
+			width = problem1.MaxNumberWidth(stems)
+		}
+
+		for i, name := range names {
+			padded := problem1.PadNumbers(stems[i], width) + exts[i]
+			if padded != name {
+				ops = append(ops, renameOp{
+					From: filepath.Join(dir, name),
+					To:   filepath.Join(dir, padded),
+				})
+			}
+		}
+	}
+
+	sortRenames(ops)
+	return ops, nil
+}
+
+// splitExt splits name into the part to pad and the extension to keep as
+// is. With extensions set, the whole name is padded.
+func splitExt(name string, extensions bool) (string, string) {
+	ext := filepath.Ext(name)
+	if extensions || ext == name {
+		return name, ""
+	}
+	return strings.TrimSuffix(name, ext), ext
+}
+
+// sortRenames orders ops so that a file is moved out of the way before
+// another one is moved onto its name. Padding only ever makes a name longer,
+// so handling longer sources first resolves every such chain.
+func sortRenames(ops []renameOp) {
+	sort.Slice(ops, func(i, j int) bool {
+		if len(ops[i].From) != len(ops[j].From) {
+			return len(ops[i].From) > len(ops[j].From)
+		}
+		return ops[i].From < ops[j].From
+	})
+}
+
+// checkRenames makes sure that no two files end up with the same name and
+// that no existing file is overwritten, before anything is renamed. A target
+// may only exist if that file is itself moved away first. Collisions are
+// refused with a usageError.
+func checkRenames(ops []renameOp) error {
+	sources := make(map[string]int, len(ops))
+	for i, op := range ops {
+		sources[op.From] = i
+	}
+
+	targets := make(map[string]string, len(ops))
+	for i, op := range ops {
+		if _, err := os.Lstat(op.From); err != nil {
+			return err
+		}
+		if other, ok := targets[op.To]; ok {
+			return usageError{fmt.Sprintf("collision: %s and %s would both be renamed to %s", other, op.From, op.To)}
+		}
+		targets[op.To] = op.From
+
+		if j, ok := sources[op.To]; ok && j < i {
+			continue
+		}
+		if _, err := os.Lstat(op.To); err == nil {
+			return usageError{fmt.Sprintf("collision: renaming %s would overwrite existing %s", op.From, op.To)}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// performRenames checks and applies ops, printing each one. Unless dryRun is
+// set, each rename is recorded in the journal before it is done, so that the
+// renames done so far can be undone even if a later one fails or the process
+// is killed.
+func performRenames(ops []renameOp, dryRun bool, journal string) error {
+	if err := checkRenames(ops); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		fmt.Printf("%s -> %s\n", op.From, op.To)
+	}
+	if dryRun || len(ops) == 0 {
+		return nil
+	}
+
+	var done []renameOp
+	for _, op := range ops {
+		if journal != "" {
+			if err := writeJournal(journal, append(done, op)); err != nil {
+				return fmt.Errorf("failed to write journal: %w", err)
+			}
+		}
+		if err := os.Rename(op.From, op.To); err != nil {
+			if journal == "" {
+				return err
+			}
+			// Drop the entry of the rename that failed
+			var journalErr error
+			if len(done) == 0 {
+				journalErr = os.Remove(journal)
+			} else {
+				journalErr = writeJournal(journal, done)
+			}
+			if journalErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to write journal: %w", journalErr))
+			}
+			return err
+		}
+		done = append(done, op)
+	}
+	return nil
+}
+
+// undoRenames replays the journal at path in reverse.
+func undoRenames(path string, dryRun bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var journal renameJournal
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return fmt.Errorf("invalid journal %s: %w", path, err)
+	}
+
+	// A run that was killed may have recorded its last rename without
+	// doing it
+	if n := len(journal.Renames); n > 0 {
+		last := journal.Renames[n-1]
+		if !exists(last.To) && exists(last.From) {
+			journal.Renames = journal.Renames[:n-1]
+		}
+	}
+
+	ops := make([]renameOp, len(journal.Renames))
+	for i, op := range journal.Renames {
+		ops[len(ops)-1-i] = renameOp{From: op.To, To: op.From}
+	}
+	return performRenames(ops, dryRun, "")
+}
+
+// writeJournal replaces the journal at path with ops. The journal is written
+// to a temporary file first, so that it is never left half written.
+func writeJournal(path string, ops []renameOp) error {
+	data, err := json.MarshalIndent(renameJournal{Renames: ops}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func createFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var names []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			names = append(names, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	slices.Sort(names)
+	return names
+}
+
+func TestRenameAndUndo(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, "img1.png", "img25.png", "img250.png", "clip7.mp4", "notes.txt", "sub/a3.txt", "sub/a12.txt")
+
+	ops, err := planRenames(dir, renameConfig{auto: true, include: "*"})
+	if err != nil {
+		t.Fatalf("planRenames() error: %v", err)
+	}
+
+	journal := filepath.Join(t.TempDir(), "journal.json")
+	if err := performRenames(ops, false, journal); err != nil {
+		t.Fatalf("performRenames() error: %v", err)
+	}
+
+	want := []string{"clip007.mp4", "img001.png", "img025.png", "img250.png", "notes.txt", "sub/a03.txt", "sub/a12.txt"}
+	if got := listFiles(t, dir); !slices.Equal(got, want) {
+		t.Errorf("after rename: %q; want %q", got, want)
+	}
+
+	if err := undoRenames(journal, false); err != nil {
+		t.Fatalf("undoRenames() error: %v", err)
+	}
+	want = []string{"clip7.mp4", "img1.png", "img25.png", "img250.png", "notes.txt", "sub/a12.txt", "sub/a3.txt"}
+	if got := listFiles(t, dir); !slices.Equal(got, want) {
+		t.Errorf("after undo: %q; want %q", got, want)
+	}
+}
+
+func TestRenameFilters(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, "a1.png", "b1.png", "c1.txt", "video.mp4")
+
+	ops, err := planRenames(dir, renameConfig{width: 3, include: "*.png", exclude: "b*"})
+	if err != nil {
+		t.Fatalf("planRenames() error: %v", err)
+	}
+	if len(ops) != 1 || filepath.Base(ops[0].To) != "a001.png" {
+		t.Errorf("planRenames() = %+v; want only a1.png -> a001.png", ops)
+	}
+
+	ops, err = planRenames(dir, renameConfig{width: 3, include: "*.mp4", extensions: true})
+	if err != nil {
+		t.Fatalf("planRenames() error: %v", err)
+	}
+	if len(ops) != 1 || filepath.Base(ops[0].To) != "video.mp004" {
+		t.Errorf("planRenames() with extensions = %+v; want video.mp4 -> video.mp004", ops)
+	}
+}
+
+func TestRenameCollision(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, "x1.txt", "x01.txt", "y2.txt", "y002.txt")
+
+	ops, err := planRenames(dir, renameConfig{width: 3, include: "*"})
+	if err != nil {
+		t.Fatalf("planRenames() error: %v", err)
+	}
+	err = performRenames(ops, false, "")
+	if err == nil {
+		t.Fatal("expected a collision error")
+	}
+	if code := exitCode(err); code != exitUsage {
+		t.Errorf("collision exit code = %d; want %d", code, exitUsage)
+	}
+
+	// Nothing may have been renamed.
+	want := []string{"x01.txt", "x1.txt", "y002.txt", "y2.txt"}
+	if got := listFiles(t, dir); !slices.Equal(got, want) {
+		t.Errorf("after failed rename: %q; want %q", got, want)
+	}
+}
+
+func TestRenameUsage(t *testing.T) {
+	for _, args := range [][]string{{"-bogus"}, {"a", "b"}, {"-undo", "j.json", "dir"}} {
+		if code := exitCode(runRename(args)); code != exitUsage {
+			t.Errorf("runRename(%q) exit code = %d; want %d", args, code, exitUsage)
+		}
+	}
+}
+
+func TestRenameChain(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, "v1", "v01")
+
+	// v01 -> v001 must happen before v1 -> v01.
+	ops := []renameOp{
+		{From: filepath.Join(dir, "v1"), To: filepath.Join(dir, "v01")},
+		{From: filepath.Join(dir, "v01"), To: filepath.Join(dir, "v001")},
+	}
+	sortRenames(ops)
+	if err := performRenames(ops, false, ""); err != nil {
+		t.Fatalf("performRenames() error: %v", err)
+	}
+	if got := listFiles(t, dir); !slices.Equal(got, []string{"v001", "v01"}) {
+		t.Errorf("after chained rename: %q", got)
+	}
+}
+
+func TestRenameJournalOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, "a1", "b1")
+
+	// The second rename fails: its target directory does not exist.
+	ops := []renameOp{
+		{From: filepath.Join(dir, "a1"), To: filepath.Join(dir, "a01")},
+		{From: filepath.Join(dir, "b1"), To: filepath.Join(dir, "missing", "b01")},
+	}
+	journal := filepath.Join(t.TempDir(), "journal.json")
+	if err := performRenames(ops, false, journal); err == nil {
+		t.Fatal("expected a rename error")
+	}
+
+	if err := undoRenames(journal, false); err != nil {
+		t.Fatalf("undoRenames() error: %v", err)
+	}
+	if got := listFiles(t, dir); !slices.Equal(got, []string{"a1", "b1"}) {
+		t.Errorf("after undo: %q; want [a1 b1]", got)
+	}
+}
+
+func TestUndoInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, "a01", "b1")
+
+	// Killed after recording b1 -> b01 but before renaming it
+	journal := filepath.Join(t.TempDir(), "journal.json")
+	err := writeJournal(journal, []renameOp{
+		{From: filepath.Join(dir, "a1"), To: filepath.Join(dir, "a01")},
+		{From: filepath.Join(dir, "b1"), To: filepath.Join(dir, "b01")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := undoRenames(journal, false); err != nil {
+		t.Fatalf("undoRenames() error: %v", err)
+	}
+	if got := listFiles(t, dir); !slices.Equal(got, []string{"a1", "b1"}) {
+		t.Errorf("after undo: %q; want [a1 b1]", got)
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"deficheck/problem1"
+)
+
+// serveConfig holds the limits of the serve subcommand.
+type serveConfig struct {
+	maxBody  int64 // bytes per request body
+	maxItems int   // strings per batch request
+	maxWidth int   // largest width, either sign, and precision
+}
+
+// runServe implements the serve subcommand.
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "Address to listen on")
+	maxBody := flags.Int64("max-body", 10<<20, "Largest request body accepted, in bytes")
+	maxItems := flags.Int("max-items", 10000, "Most strings accepted in one batch request")
+	maxWidth := flags.Int("max-width", 1024, "Largest width or precision accepted")
+	flags.Usage = func() {
+		fmt.Println("Usage: padder serve [options]")
+		fmt.Println()
+		fmt.Println("Endpoints:")
+		fmt.Println("  POST /v1/pad         JSON batch: {\"options\": {...}, \"items\": [{\"text\": \"...\", \"options\": {...}}]}")
+		fmt.Println("  POST /v1/pad/stream  plain text body, options as query parameters (?width=3)")
+		fmt.Println("  GET  /healthz        health check")
+		fmt.Println()
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(exitUsage)
+	}
+	if *maxBody <= 0 || *maxItems <= 0 || *maxWidth <= 0 {
+		return usageError{"-max-body, -max-items and -max-width must be positive"}
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newServeMux(serveConfig{maxBody: *maxBody, maxItems: *maxItems, maxWidth: *maxWidth}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Finish the requests in flight on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening on %s\n", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func newServeMux(cfg serveConfig) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("POST /v1/pad", cfg.handleBatch)
+	mux.HandleFunc("POST /v1/pad/stream", cfg.handleStream)
+	return mux
+}
+
+// padOptions is the JSON and query parameter form of problem1.Options. The
+// zero value pads nothing, like width 0.
+type padOptions struct {
+	Width        int    `json:"width"`
+	PadChar      string `json:"padChar"`      // a single character, "0" if empty (" " with align=left)
+	Align        string `json:"align"`        // "right" (default) or "left"
+	Mode         string `json:"mode"`         // "min" (default) or "exact"
+	Overflow     string `json:"overflow"`     // "leave" (default), "truncate" or "error"
+	LeadingZeros string `json:"leadingZeros"` // "count" (default) or "ignore"
+	Signed       bool   `json:"signed"`
+	Locale       string `json:"locale"` // "", "en", "de" or "fr"
+	Literals     bool   `json:"literals"`
+	Precision    int    `json:"precision"`
+	Round        bool   `json:"round"`
+}
+
+// options converts o, rejecting unknown values and a width or precision
+// beyond maxWidth, which would let a client make the server pad without
+// bound.
+func (o padOptions) options(maxWidth int) (problem1.Options, error) {
+	opts := problem1.Options{
+		Width:     o.Width,
+		Signed:    o.Signed,
+		Precision: o.Precision,
+	}
+
+	if o.Width > maxWidth || o.Width < -maxWidth {
+		return opts, fmt.Errorf("width %d exceeds the limit of %d", o.Width, maxWidth)
+	}
+	if o.Precision > maxWidth {
+		return opts, fmt.Errorf("precision %d exceeds the limit of %d", o.Precision, maxWidth)
+	}
+
+	if o.PadChar != "" {
+		r, size := utf8.DecodeRuneInString(o.PadChar)
+		if r == utf8.RuneError || size != len(o.PadChar) {
+			return opts, fmt.Errorf("padChar must be a single character, got %q", o.PadChar)
+		}
+		opts.PadChar = r
+	}
+
+	var err error
+	choose := func(name, value string, choices ...string) int {
+		if value == "" {
+			return 0
+		}
+		for k, c := range choices {
+			if value == c {
+				return k
+			}
+		}
+		if err == nil {
+			err = fmt.Errorf("unknown %s %q", name, value)
+		}
+		return 0
+	}
+	opts.Align = problem1.Alignment(choose("align", o.Align, "right", "left"))
+	opts.Mode = problem1.WidthMode(choose("mode", o.Mode, "min", "exact"))
+	opts.Overflow = problem1.OverflowPolicy(choose("overflow", o.Overflow, "leave", "truncate", "error"))
+	opts.LeadingZeros = problem1.LeadingZeroPolicy(choose("leadingZeros", o.LeadingZeros, "count", "ignore"))
+	opts.Locale = []problem1.Locale{{}, problem1.LocaleEN, problem1.LocaleDE, problem1.LocaleFR}[choose("locale", o.Locale, "", "en", "de", "fr")]
+	if err != nil {
+		return opts, err
+	}
+
+	if o.Literals {
+		opts.Literals = problem1.DefaultLiterals
+	}
+	if o.Round {
+		opts.Fraction = problem1.FractionRound
+	}
+	return opts, nil
+}
+
+// queryOptions reads padOptions from query parameters named like the JSON
+// fields.
+func queryOptions(q url.Values) (padOptions, error) {
+	var o padOptions
+	var err error
+	number := func(name string) int {
+		s := q.Get(name)
+		if s == "" {
+			return 0
+		}
+		n, convErr := strconv.Atoi(s)
+		if convErr != nil && err == nil {
+			err = fmt.Errorf("invalid %s %q", name, s)
+		}
+		return n
+	}
+	boolean := func(name string) bool {
+		s := q.Get(name)
+		if s == "" {
+			return false
+		}
+		b, convErr := strconv.ParseBool(s)
+		if convErr != nil && err == nil {
+			err = fmt.Errorf("invalid %s %q", name, s)
+		}
+		return b
+	}
+
+	o.Width = number("width")
+	o.Precision = number("precision")
+	o.Signed = boolean("signed")
+	o.Literals = boolean("literals")
+	o.Round = boolean("round")
+	o.PadChar = q.Get("padChar")
+	o.Align = q.Get("align")
+	o.Mode = q.Get("mode")
+	o.Overflow = q.Get("overflow")
+	o.LeadingZeros = q.Get("leadingZeros")
+	o.Locale = q.Get("locale")
+	return o, err
+}
+
+type batchRequest struct {
+	Options padOptions  `json:"options"` // defaults for every item
+	Items   []batchItem `json:"items"`
+}
+
+type batchItem struct {
+	Text string `json:"text"`
+	// Options overrides the request's defaults field by field.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+type batchResult struct {
+	Text  string `json:"text"`
+	Error string `json:"error,omitempty"`
+}
+
+// handleBatch pads every item of a JSON batch. An item whose options are
+// invalid or whose padding fails gets an error and no text; the others are
+// still padded.
+func (cfg serveConfig) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, cfg.maxBody))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&req)
+	if err == nil {
+		// Only whitespace may follow the request object
+		if err = dec.Decode(&struct{}{}); err == io.EOF {
+			err = nil
+		} else if err == nil {
+			err = errors.New("unexpected data after the request object")
+		}
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", cfg.maxBody))
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+	if len(req.Items) > cfg.maxItems {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("batch exceeds %d items", cfg.maxItems))
+		return
+	}
+	if _, err := req.Options.options(cfg.maxWidth); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid options: "+err.Error())
+		return
+	}
+
+	results := make([]batchResult, len(req.Items))
+	for k, item := range req.Items {
+		o := req.Options
+		if item.Options != nil {
+			dec := json.NewDecoder(bytes.NewReader(item.Options))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&o); err != nil {
+				results[k].Error = "invalid options: " + err.Error()
+				continue
+			}
+		}
+		opts, err := o.options(cfg.maxWidth)
+		if err != nil {
+			results[k].Error = "invalid options: " + err.Error()
+			continue
+		}
+		if results[k].Text, err = problem1.PadNumbersWithOptions(item.Text, opts); err != nil {
+			results[k].Error = err.Error()
+		}
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Results []batchResult `json:"results"`
+	}{results})
+}
+
+// handleStream pads a plain-text body into the response as it arrives. The
+// status is sent before the body is read, so an error part way through, such
+// as the size limit, aborts the response instead.
+func (cfg serveConfig) handleStream(w http.ResponseWriter, r *http.Request) {
+	o, err := queryOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid options: "+err.Error())
+		return
+	}
+	opts, err := o.options(cfg.maxWidth)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid options: "+err.Error())
+		return
+	}
+	if r.ContentLength > cfg.maxBody {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", cfg.maxBody))
+		return
+	}
+
+	// HTTP/1 servers stop reading the body once the response starts unless
+	// told otherwise.
+	http.NewResponseController(w).EnableFullDuplex()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	body := http.MaxBytesReader(w, r.Body, cfg.maxBody)
+	if _, err := io.Copy(w, problem1.NewPadReaderWithOptions(body, opts)); err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	// Keep '<', '>' and '&' as they are in padded text
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{msg})
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"deficheck/problem1"
+)
+
+func TestServeBatch(t *testing.T) {
+	srv := httptest.NewServer(newServeMux(serveConfig{maxBody: 1 << 20, maxItems: 10, maxWidth: 100}))
+	defer srv.Close()
+
+	body := `{
+		"options": {"width": 3},
+		"items": [
+			{"text": "James Bond 7"},
+			{"text": "PI=3.14 <a&b>"},
+			{"text": "x -7", "options": {"signed": true, "width": 4}},
+			{"text": "Preis 3,5", "options": {"locale": "de", "precision": 2}},
+			{"text": "1234", "options": {"mode": "exact", "overflow": "error"}},
+			{"text": "7", "options": {"align": "sideways"}},
+			{"text": "7", "options": {"width": 1000}}
+		]
+	}`
+	resp, err := http.Post(srv.URL+"/v1/pad", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d; want 200", resp.StatusCode)
+	}
+
+	var got struct {
+		Results []batchResult `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	signed, _ := problem1.PadNumbersWithOptions("x -7", problem1.Options{Width: 4, Signed: true})
+	german, _ := problem1.PadNumbersWithOptions("Preis 3,5", problem1.Options{Width: 3, Locale: problem1.LocaleDE, Precision: 2})
+	want := []batchResult{
+		{Text: problem1.PadNumbers("James Bond 7", 3)},
+		{Text: problem1.PadNumbers("PI=3.14 <a&b>", 3)},
+		{Text: signed},
+		{Text: german},
+		{Error: "number exceeds width: 1234 is wider than 3"},
+		{Error: `invalid options: unknown align "sideways"`},
+		{Error: "invalid options: width 1000 exceeds the limit of 100"},
+	}
+	if len(got.Results) != len(want) {
+		t.Fatalf("got %d results; want %d", len(got.Results), len(want))
+	}
+	for k := range want {
+		if got.Results[k] != want[k] {
+			t.Errorf("result %d = %+v; want %+v", k, got.Results[k], want[k])
+		}
+	}
+}
+
+func TestServeStream(t *testing.T) {
+	srv := httptest.NewServer(newServeMux(serveConfig{maxBody: 1 << 20, maxItems: 10, maxWidth: 100}))
+	defer srv.Close()
+
+	input := strings.Repeat("row 7 value 3.14159 id 42 Цена ٣\n", 2000)
+	resp, err := http.Post(srv.URL+"/v1/pad/stream?width=5", "text/plain", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(got) != problem1.PadNumbers(input, 5) {
+		t.Errorf("stream status %d, output differs from PadNumbers", resp.StatusCode)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	srv := httptest.NewServer(newServeMux(serveConfig{maxBody: 100, maxItems: 2, maxWidth: 10}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"Health", http.MethodGet, "/healthz", "", http.StatusOK},
+		{"Batch with GET", http.MethodGet, "/v1/pad", "", http.StatusMethodNotAllowed},
+		{"Invalid JSON", http.MethodPost, "/v1/pad", "{", http.StatusBadRequest},
+		{"Trailing garbage", http.MethodPost, "/v1/pad", `{"items": []} garbage`, http.StatusBadRequest},
+		{"Trailing object", http.MethodPost, "/v1/pad", `{"items": []} {}`, http.StatusBadRequest},
+		{"Trailing whitespace", http.MethodPost, "/v1/pad", "{\"items\": []}\n", http.StatusOK},
+		{"Unknown field", http.MethodPost, "/v1/pad", `{"item": []}`, http.StatusBadRequest},
+		{"Invalid defaults", http.MethodPost, "/v1/pad", `{"options": {"mode": "x"}}`, http.StatusBadRequest},
+		{"Too many items", http.MethodPost, "/v1/pad", `{"items": [{}, {}, {}]}`, http.StatusRequestEntityTooLarge},
+		{"Batch too large", http.MethodPost, "/v1/pad", `{"items": [{"text": "` + strings.Repeat("x", 100) + `"}]}`, http.StatusRequestEntityTooLarge},
+		{"Stream too large", http.MethodPost, "/v1/pad/stream?width=2", strings.Repeat("x", 101), http.StatusRequestEntityTooLarge},
+		{"Width too large", http.MethodPost, "/v1/pad", `{"items": [{"text": "1"}], "options": {"width": 1000000000000}}`, http.StatusBadRequest},
+		{"Width too small", http.MethodPost, "/v1/pad", `{"options": {"width": -11}}`, http.StatusBadRequest},
+		{"Precision too large", http.MethodPost, "/v1/pad", `{"options": {"precision": 11}}`, http.StatusBadRequest},
+		{"Width at limit", http.MethodPost, "/v1/pad", `{"options": {"width": 10, "precision": 10}}`, http.StatusOK},
+		{"Stream width too large", http.MethodPost, "/v1/pad/stream?width=1000000000000", "7", http.StatusBadRequest},
+		{"Stream precision too large", http.MethodPost, "/v1/pad/stream?width=2&precision=11", "7", http.StatusBadRequest},
+		{"Stream bad width", http.MethodPost, "/v1/pad/stream?width=x", "7", http.StatusBadRequest},
+		{"Stream bad option", http.MethodPost, "/v1/pad/stream?width=2&locale=xx", "7", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("%s %s: status = %d; want %d", tt.method, tt.path, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
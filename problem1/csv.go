@@ -0,0 +1,194 @@
+package problem1
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSVOptions configures PadCSV.
+type CSVOptions struct {
+	// Options controls how numbers are padded.
+	Options
+
+	// Columns selects the columns to pad: a name from the header, or "#N"
+	// for the N-th column counting from 1. Every column is padded if empty.
+	Columns []string
+
+	// Header means the first record names the columns. It is copied
+	// unchanged. Selecting a column by name implies a header.
+	Header bool
+
+	// Comma is the field delimiter, ',' if zero.
+	Comma rune
+}
+
+// PadCSV copies the CSV document in r to w, padding the numbers in the
+// selected columns only. Everything else, including quoting, spacing and line
+// endings, is copied byte for byte. Quoted fields may contain delimiters and
+// line breaks; numbers inside them are padded like any other text.
+func PadCSV(w io.Writer, r io.Reader, opts CSVOptions) error {
+	comma := opts.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	if comma == '"' || comma == '\r' || comma == '\n' || !utf8.ValidRune(comma) {
+		return fmt.Errorf("csv: invalid delimiter %q", comma)
+	}
+	var buf [utf8.UTFMax]byte
+	sep := buf[:utf8.EncodeRune(buf[:], comma)]
+
+	selectAll := len(opts.Columns) == 0
+	var indexes []int // selected 0-based columns
+	var names []string
+	header := opts.Header
+	for _, c := range opts.Columns {
+		if n, ok := strings.CutPrefix(c, "#"); ok {
+			k, err := strconv.Atoi(n)
+			if err != nil || k < 1 {
+				return fmt.Errorf("csv: invalid column %q", c)
+			}
+			indexes = append(indexes, k-1)
+			continue
+		}
+		names = append(names, c)
+		header = true
+	}
+
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	var selected []bool
+	var out []byte
+	for first := true; ; first = false {
+		record, err := readCSVRecord(br)
+		if len(record) > 0 {
+			body, eol := splitLineEnd(record)
+			fields := splitCSVFields(body, sep)
+
+			if first && header {
+				var selErr error
+				if selected, selErr = selectColumns(fields, names, indexes); selErr != nil {
+					return selErr
+				}
+				bw.Write(record)
+			} else {
+				out = out[:0]
+				for k, f := range fields {
+					if k > 0 {
+						out = append(out, sep...)
+					}
+					if selectAll || k < len(selected) && selected[k] || !header && slices.Contains(indexes, k) {
+						var padErr error
+						p := padder{opts: opts.Options}
+						if out, _, padErr = p.pad(out, f, true); padErr != nil {
+							return padErr
+						}
+					} else {
+						out = append(out, f...)
+					}
+				}
+				out = append(out, eol...)
+				bw.Write(out)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// selectColumns maps the header fields and the selected names and indexes to
+// a per-column selection.
+func selectColumns(header [][]byte, names []string, indexes []int) ([]bool, error) {
+	selected := make([]bool, len(header))
+	for _, k := range indexes {
+		if k < len(selected) {
+			selected[k] = true
+		}
+	}
+	for _, name := range names {
+		found := false
+		for k, f := range header {
+			if unquoteCSV(f) == name {
+				selected[k] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("csv: no column named %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// readCSVRecord reads one record, including its line ending. A record spans
+// several lines when a quoted field contains a line break.
+func readCSVRecord(br *bufio.Reader) ([]byte, error) {
+	var record []byte
+	quotes := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		record = append(record, line...)
+		quotes += bytes.Count(line, []byte{'"'})
+		// An odd number of quotes means a quoted field is still open
+		if quotes%2 == 0 || err != nil {
+			if err == io.EOF && quotes%2 != 0 {
+				err = errors.New("csv: unterminated quoted field")
+			}
+			return record, err
+		}
+	}
+}
+
+// splitLineEnd splits the trailing "\n" or "\r\n" off a record.
+func splitLineEnd(record []byte) ([]byte, []byte) {
+	n := len(record)
+	switch {
+	case bytes.HasSuffix(record, []byte("\r\n")):
+		n -= 2
+	case bytes.HasSuffix(record, []byte("\n")):
+		n--
+	}
+	return record[:n], record[n:]
+}
+
+// splitCSVFields splits a record without its line ending into raw fields,
+// quotes included. Delimiters inside quotes do not split.
+func splitCSVFields(body, sep []byte) [][]byte {
+	var fields [][]byte
+	start := 0
+	quoted := false
+	for i := 0; i < len(body); {
+		switch {
+		case body[i] == '"':
+			quoted = !quoted
+			i++
+		case !quoted && bytes.HasPrefix(body[i:], sep):
+			fields = append(fields, body[start:i])
+			i += len(sep)
+			start = i
+		default:
+			i++
+		}
+	}
+	return append(fields, body[start:])
+}
+
+// unquoteCSV returns the value of a raw field.
+func unquoteCSV(f []byte) string {
+	s := string(f)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return s
+}
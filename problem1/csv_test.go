@@ -0,0 +1,69 @@
+package problem1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPadCSV(t *testing.T) {
+	doc := "id,name,qty\r\n7,\"Widget 5, blue\",12\r\n42,\"say \"\"3\"\"\",7\r\n"
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     CSVOptions
+		expected string
+	}{
+		{"Column by name", doc, CSVOptions{Options: Options{Width: 3}, Columns: []string{"qty"}},
+			"id,name,qty\r\n7,\"Widget 5, blue\",012\r\n42,\"say \"\"3\"\"\",007\r\n"},
+		{"Quoted column", doc, CSVOptions{Options: Options{Width: 2}, Columns: []string{"name"}},
+			"id,name,qty\r\n7,\"Widget 05, blue\",12\r\n42,\"say \"\"03\"\"\",7\r\n"},
+		{"Column by index with header", doc, CSVOptions{Options: Options{Width: 3}, Columns: []string{"#1"}, Header: true},
+			"id,name,qty\r\n007,\"Widget 5, blue\",12\r\n042,\"say \"\"3\"\"\",7\r\n"},
+		{"Column by index without header", "1,2\n3,4", CSVOptions{Options: Options{Width: 2}, Columns: []string{"#2"}},
+			"1,02\n3,04"},
+		{"All columns", "a1,2\n", CSVOptions{Options: Options{Width: 2}}, "a01,02\n"},
+		{"Header never padded", "c1,c2\n1,2\n", CSVOptions{Options: Options{Width: 2}, Header: true}, "c1,c2\n01,02\n"},
+		{"Quoted header name", "\"my id\",x\n5,5\n", CSVOptions{Options: Options{Width: 2}, Columns: []string{"my id"}},
+			"\"my id\",x\n05,5\n"},
+		{"Line break in quotes", "n,t\n1,\"a\n2\"\n", CSVOptions{Options: Options{Width: 2}, Columns: []string{"t"}},
+			"n,t\n1,\"a\n02\"\n"},
+		{"Semicolons", "1;2,5\n", CSVOptions{Options: Options{Width: 2}, Columns: []string{"#2"}, Comma: ';'}, "1;02,05\n"},
+		{"Missing fields", "a,b,c\n1\n", CSVOptions{Options: Options{Width: 2}, Columns: []string{"c"}}, "a,b,c\n1\n"},
+		{"Empty input", "", CSVOptions{Options: Options{Width: 2}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := PadCSV(&out, strings.NewReader(tt.input), tt.opts); err != nil {
+				t.Fatalf("PadCSV(%q) error: %v", tt.input, err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("PadCSV(%q) = %q; want %q", tt.input, out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  CSVOptions
+	}{
+		{"Unknown column", "a,b\n1,2\n", CSVOptions{Columns: []string{"c"}}},
+		{"Bad index", "1,2\n", CSVOptions{Columns: []string{"#0"}}},
+		{"Quote delimiter", "1,2\n", CSVOptions{Comma: '"'}},
+		{"Unterminated quote", "1,\"2\n", CSVOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := PadCSV(&out, strings.NewReader(tt.input), tt.opts); err == nil {
+				t.Errorf("PadCSV(%q) succeeded; want error", tt.input)
+			}
+		})
+	}
+}
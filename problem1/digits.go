@@ -0,0 +1,55 @@
+package problem1
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// digitZero returns the zero digit of the script r belongs to, or -1 if r is
+// not a decimal digit (Unicode category Nd). Every Nd range in the Unicode
+// tables is made of whole blocks of ten consecutive digits starting at zero,
+// so the zero can be derived from the range that contains r.
+func digitZero(r rune) rune {
+	if r >= '0' && r <= '9' {
+		return '0'
+	}
+	if r < utf8.RuneSelf {
+		return -1
+	}
+	for _, rng := range unicode.Nd.R16 {
+		if r < rune(rng.Lo) {
+			return -1
+		}
+		if r <= rune(rng.Hi) {
+			lo := rune(rng.Lo)
+			return lo + (r-lo)/10*10
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if r < rune(rng.Lo) {
+			return -1
+		}
+		if r <= rune(rng.Hi) {
+			lo := rune(rng.Lo)
+			return lo + (r-lo)/10*10
+		}
+	}
+	return -1
+}
+
+// decodeDigit decodes the rune at the start of b and returns it together with
+// its size and the zero of its script; zero is -1 if it is not a digit.
+func decodeDigit(b []byte) (r rune, size int, zero rune) {
+	if len(b) > 0 && b[0] < utf8.RuneSelf {
+		r, size = rune(b[0]), 1
+	} else {
+		r, size = utf8.DecodeRune(b)
+	}
+	return r, size, digitZero(r)
+}
+
+// startsWithDigit reports whether b begins with a decimal digit of any script.
+func startsWithDigit(b []byte) bool {
+	_, _, zero := decodeDigit(b)
+	return zero >= 0
+}
@@ -0,0 +1,110 @@
+package problem1
+
+import "testing"
+
+func TestDigitZero(t *testing.T) {
+	tests := []struct {
+		r    rune
+		zero rune
+	}{
+		{'7', '0'},
+		{'７', '０'}, // full-width
+		{'٣', '٠'}, // Arabic-Indic
+		{'۵', '۰'}, // Extended Arabic-Indic
+		{'९', '०'}, // Devanagari
+		{'𝟗', '𝟎'}, // Mathematical bold, first of several adjacent blocks
+		{'𝟫', '𝟢'}, // Mathematical sans-serif, a later block in the same range
+		{'a', -1},
+		{'½', -1}, // No (vulgar fraction), not Nd
+		{'Ⅶ', -1}, // Nl, not Nd
+	}
+
+	for _, tt := range tests {
+		if got := digitZero(tt.r); got != tt.zero {
+			t.Errorf("digitZero(%q) = %q; want %q", tt.r, got, tt.zero)
+		}
+	}
+}
+
+func TestPadNumbersUnicodeDigits(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "Full-width digits",
+			input:    "第７話",
+			opts:     Options{Width: 3},
+			expected: "第００７話",
+		},
+		{
+			name:     "Arabic-Indic digits",
+			input:    "رقم ٤٢",
+			opts:     Options{Width: 4},
+			expected: "رقم ٠٠٤٢",
+		},
+		{
+			name:     "Devanagari digits",
+			input:    "पृष्ठ ५",
+			opts:     Options{Width: 2},
+			expected: "पृष्ठ ०५",
+		},
+		{
+			name:     "Fraction in another script",
+			input:    "３.１４",
+			opts:     Options{Width: 2},
+			expected: "０３.１４",
+		},
+		{
+			name:     "Space padding",
+			input:    "７",
+			opts:     Options{Width: 3, PadChar: ' '},
+			expected: "  ７",
+		},
+		{
+			name:     "Mixed scripts are separate numbers",
+			input:    "１2",
+			opts:     Options{Width: 2},
+			expected: "０１02",
+		},
+		{
+			name:     "Mixed scripts joined",
+			input:    "１2",
+			opts:     Options{Width: 3, JoinMixedScripts: true},
+			expected: "０１2",
+		},
+		{
+			name:     "Signed full-width number",
+			input:    "-７",
+			opts:     Options{Width: 3, Signed: true},
+			expected: "-０７",
+		},
+		{
+			name:     "Leading zeros ignored",
+			input:    "００７",
+			opts:     Options{Width: 2, LeadingZeros: LeadingZerosIgnore},
+			expected: "０７",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PadNumbersWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersWithOptions(%q, %+v) unexpected error: %v", tt.input, tt.opts, err)
+			}
+			if result != tt.expected {
+				t.Errorf("PadNumbersWithOptions(%q, %+v) = %q; want %q",
+					tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadWriterMultibyteAtChunkBoundary(t *testing.T) {
+	input := "第７話 ٤٢.٥ x-９ café 12"
+	// Splitting inside a multibyte sequence must not corrupt it.
+	checkSplits(t, input, Options{Width: 3, Signed: true})
+}
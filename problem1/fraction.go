@@ -0,0 +1,140 @@
+package problem1
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// FractionPolicy decides what happens to a fraction with more digits than
+// Options.Precision.
+type FractionPolicy int
+
+const (
+	// FractionKeep leaves longer fractions unchanged.
+	FractionKeep FractionPolicy = iota
+	// FractionRound rounds longer fractions to Precision digits, halfway
+	// cases to the even digit ("2.25" -> "2.2", "2.35" -> "2.4" for
+	// precision 1). A carry reaches the whole number ("9.96" -> "10.0").
+	FractionRound
+)
+
+// fraction returns the bounds of the fraction digits that follow a decimal
+// mark at src[i], or i, i if there is none. It reports false when the answer
+// depends on input beyond the end of src.
+func (p *padder) fraction(src []byte, i int, zero rune, final bool) (int, int, bool) {
+	var buf [utf8.UTFMax]byte
+	mark := buf[:utf8.EncodeRune(buf[:], p.opts.Locale.decimal())]
+
+	rest := src[i:]
+	if !bytes.HasPrefix(rest, mark) {
+		if !final && len(rest) < len(mark) && bytes.HasPrefix(mark, rest) {
+			return 0, 0, false
+		}
+		return i, i, true
+	}
+
+	start := i + len(mark)
+	end, n, ok := p.digitRun(src, start, zero, 0, final)
+	if !ok {
+		return 0, 0, false
+	}
+	if n == 0 {
+		return i, i, true
+	}
+	return start, end, true
+}
+
+// appendDecimal appends the whole number tok, padded to width, followed by
+// its fraction normalized to o.Precision digits.
+func (o *Options) appendDecimal(dst []byte, width int, tok token) ([]byte, error) {
+	whole, frac := tok.text, tok.frac
+	n := utf8.RuneCount(frac)
+	if n > o.Precision && o.Fraction == FractionRound {
+		k := 0
+		for range o.Precision {
+			_, size := utf8.DecodeRune(frac[k:])
+			k += size
+		}
+		kept, dropped := frac[:k], frac[k:]
+		if roundsUp(dropped, kept) {
+			var carry bool
+			if kept, carry = o.increment(kept); carry {
+				whole = o.incrementWhole(whole)
+			}
+		}
+		frac, n = kept, o.Precision
+	}
+
+	dst, err := o.appendNumber(dst, width, tok.sign, whole, tok.zero)
+	if err != nil {
+		return dst, err
+	}
+	dst = utf8.AppendRune(dst, o.Locale.decimal())
+	dst = append(dst, frac...)
+	return appendPadding(dst, tok.zero, o.Precision-n), nil
+}
+
+// roundsUp reports whether dropping the digits in dropped rounds the number
+// up. Halfway cases round to even, judged on the last digit of kept.
+func roundsUp(dropped, kept []byte) bool {
+	first, size := digitValue(dropped)
+	if first != 5 {
+		return first > 5
+	}
+	for rest := dropped[size:]; len(rest) > 0; rest = rest[size:] {
+		var v int
+		if v, size = digitValue(rest); v != 0 {
+			return true
+		}
+	}
+	_, size = utf8.DecodeLastRune(kept)
+	v, _ := digitValue(kept[len(kept)-size:])
+	return v%2 == 1
+}
+
+// increment returns a copy of the digits in num plus one in the last place,
+// skipping grouping marks, and reports whether a carry is left over. Digits
+// of a script all have the same UTF-8 length, so the copy is as long as num.
+func (o *Options) increment(num []byte) ([]byte, bool) {
+	out := bytes.Clone(num)
+	for k := len(out); k > 0; {
+		r, size := utf8.DecodeLastRune(out[:k])
+		k -= size
+		if r == o.Locale.Grouping {
+			continue
+		}
+		v, _ := digitValue(out[k:])
+		zero := r - rune(v)
+		if v < 9 {
+			utf8.EncodeRune(out[k:], zero+rune(v)+1)
+			return out, false
+		}
+		utf8.EncodeRune(out[k:], zero)
+	}
+	return out, true
+}
+
+// incrementWhole returns the whole number num plus one. When all its digits
+// are nines a new leading digit is added, followed by a grouping mark if num
+// is grouped and its leading group is full ("999,999" -> "1,000,000").
+func (o *Options) incrementWhole(num []byte) []byte {
+	out, carry := o.increment(num)
+	if !carry {
+		return out
+	}
+
+	_, _, zero := decodeDigit(num)
+	grown := utf8.AppendRune(nil, zero+1)
+	if o.Locale.Grouping != 0 {
+		if k := bytes.IndexRune(num, o.Locale.Grouping); k >= 0 && utf8.RuneCount(num[:k]) == o.Locale.groupSize() {
+			grown = utf8.AppendRune(grown, o.Locale.Grouping)
+		}
+	}
+	return append(grown, out...)
+}
+
+// digitValue returns the value of the digit at the start of b and its size.
+func digitValue(b []byte) (int, int) {
+	r, size, zero := decodeDigit(b)
+	return int(r - zero), size
+}
@@ -0,0 +1,61 @@
+package problem1
+
+import "testing"
+
+func TestPadNumbersPrecision(t *testing.T) {
+	round := func(width, precision int) Options {
+		return Options{Width: width, Precision: precision, Fraction: FractionRound}
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{"Pad fraction", "PI=3.1", Options{Width: 2, Precision: 3}, "PI=03.100"},
+		{"Integers untouched", "7 items, 12 left", Options{Width: 2, Precision: 2}, "07 items, 12 left"},
+		{"Longer fraction kept", "3.14159", Options{Width: 2, Precision: 2}, "03.14159"},
+		{"Exact precision", "2.50", round(1, 2), "2.50"},
+		{"Round down", "3.14159", round(2, 2), "03.14"},
+		{"Round up", "3.14159", round(1, 3), "3.142"},
+		{"Tie to even down", "2.25", round(1, 1), "2.2"},
+		{"Tie to even up", "2.35", round(1, 1), "2.4"},
+		{"Above tie", "2.2501", round(1, 1), "2.3"},
+		{"Carry into whole", "9.96", round(3, 1), "010.0"},
+		{"Carry through nines", "199.999", round(1, 2), "200.00"},
+		{"Zero whole part", "0.05", round(1, 1), "0.0"},
+		{"Signed", "x -2.345", Options{Width: 4, Signed: true, Precision: 2, Fraction: FractionRound}, "x -002.34"},
+		{"Dot without digits", "End 5.", Options{Width: 2, Precision: 2}, "End 05."},
+		{"Standalone fraction", "v.5", Options{Width: 2, Precision: 2}, "v.5"},
+		{"Dotted run", "1.2.3", Options{Width: 2, Precision: 2}, "01.20.3"},
+		{"Grouped round", "999,999.96", Options{Locale: LocaleEN, Precision: 1, Fraction: FractionRound}, "1,000,000.0"},
+		{"Ungrouped stays ungrouped", "999.96", Options{Locale: LocaleEN, Precision: 1, Fraction: FractionRound}, "1000.0"},
+		{"German locale", "Preis 3,5 EUR", Options{Width: 2, Locale: LocaleDE, Precision: 2}, "Preis 03,50 EUR"},
+		{"Arabic-Indic digits", "٣.١", Options{Width: 2, Precision: 2}, "٠٣.١٠"},
+		{"Arabic-Indic carry", "٩.٩٦", round(1, 1), "١٠.٠"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PadNumbersWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersWithOptions(%q) error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("PadNumbersWithOptions(%q, %+v) = %q; want %q", tt.input, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadWriterPrecisionAtChunkBoundary(t *testing.T) {
+	input := "a 3.1 b 9.96 c 12. d 0.125 e 4"
+	for _, opts := range []Options{
+		{Width: 3, Precision: 2},
+		{Width: 3, Precision: 2, Fraction: FractionRound},
+		{Width: 3, Precision: 1, Fraction: FractionRound, Locale: LocaleDE},
+	} {
+		checkSplits(t, input, opts)
+	}
+}
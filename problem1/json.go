@@ -0,0 +1,262 @@
+package problem1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// PadJSON copies the JSON document in r to w, padding the numbers inside the
+// string values found at the given paths. Keys, numeric literals and all
+// other values are never changed, and whitespace and escapes are copied as
+// they are.
+//
+// Paths are JSON Pointers (RFC 6901) in which a "*" segment matches any key
+// or array index: "/items/*/name". The pointer "" selects the document
+// itself. With no paths every string value is padded.
+func PadJSON(w io.Writer, r io.Reader, paths []string, opts Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		return errors.New("json: invalid document")
+	}
+
+	jp := jsonPadder{data: data, opts: opts, all: len(paths) == 0}
+	for _, path := range paths {
+		segments, err := parsePointer(path)
+		if err != nil {
+			return err
+		}
+		jp.paths = append(jp.paths, segments)
+	}
+
+	i := jp.value(jp.space(0))
+	jp.space(i)
+	if jp.err != nil {
+		return jp.err
+	}
+	_, err = w.Write(jp.out)
+	return err
+}
+
+// parsePointer splits a JSON Pointer into unescaped segments.
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if path[0] != '/' {
+		return nil, fmt.Errorf("json: invalid pointer %q: must start with '/'", path)
+	}
+	segments := strings.Split(path[1:], "/")
+	for k, s := range segments {
+		segments[k] = strings.NewReplacer("~1", "/", "~0", "~").Replace(s)
+	}
+	return segments, nil
+}
+
+// jsonPadder walks a valid JSON document, copying it to out.
+type jsonPadder struct {
+	data  []byte
+	out   []byte
+	opts  Options
+	paths [][]string
+	all   bool
+	path  []string // location of the current value
+	err   error
+}
+
+// space copies the whitespace at data[i] and returns the index after it.
+func (j *jsonPadder) space(i int) int {
+	start := i
+	for i < len(j.data) {
+		switch j.data[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+			continue
+		}
+		break
+	}
+	j.out = append(j.out, j.data[start:i]...)
+	return i
+}
+
+// value copies the value at data[i] and returns the index after it.
+func (j *jsonPadder) value(i int) int {
+	switch j.data[i] {
+	case '{':
+		return j.object(i)
+	case '[':
+		return j.array(i)
+	case '"':
+		end := stringEnd(j.data, i)
+		if j.selected() {
+			j.padString(j.data[i:end])
+		} else {
+			j.out = append(j.out, j.data[i:end]...)
+		}
+		return end
+	}
+
+	// Number, true, false or null
+	end := i
+	for end < len(j.data) && !strings.ContainsRune(",]} \t\n\r", rune(j.data[end])) {
+		end++
+	}
+	j.out = append(j.out, j.data[i:end]...)
+	return end
+}
+
+func (j *jsonPadder) object(i int) int {
+	j.out = append(j.out, '{')
+	i = j.space(i + 1)
+	if j.data[i] == '}' {
+		j.out = append(j.out, '}')
+		return i + 1
+	}
+
+	for {
+		end := stringEnd(j.data, i)
+		var key string
+		if err := json.Unmarshal(j.data[i:end], &key); err != nil && j.err == nil {
+			j.err = err
+		}
+		j.out = append(j.out, j.data[i:end]...)
+
+		i = j.space(end)
+		j.out = append(j.out, ':') // data[i] is ':'
+		i = j.space(i + 1)
+
+		j.path = append(j.path, key)
+		i = j.space(j.value(i))
+		j.path = j.path[:len(j.path)-1]
+
+		j.out = append(j.out, j.data[i])
+		if j.data[i] == '}' {
+			return i + 1
+		}
+		i = j.space(i + 1)
+	}
+}
+
+func (j *jsonPadder) array(i int) int {
+	j.out = append(j.out, '[')
+	i = j.space(i + 1)
+	if j.data[i] == ']' {
+		j.out = append(j.out, ']')
+		return i + 1
+	}
+
+	for index := 0; ; index++ {
+		j.path = append(j.path, strconv.Itoa(index))
+		i = j.space(j.value(i))
+		j.path = j.path[:len(j.path)-1]
+
+		j.out = append(j.out, j.data[i])
+		if j.data[i] == ']' {
+			return i + 1
+		}
+		i = j.space(i + 1)
+	}
+}
+
+// selected reports whether the current path matches one of the paths.
+func (j *jsonPadder) selected() bool {
+	if j.all {
+		return true
+	}
+	for _, p := range j.paths {
+		if len(p) != len(j.path) {
+			continue
+		}
+		match := true
+		for k, s := range p {
+			if s != "*" && s != j.path[k] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// padString pads the numbers in the raw string literal s. Escape sequences
+// are copied unchanged and end any number, just as the characters they stand
+// for would.
+func (j *jsonPadder) padString(s []byte) {
+	p := padder{opts: j.opts}
+	j.out = append(j.out, '"')
+	body := s[1 : len(s)-1]
+	for len(body) > 0 {
+		k := 0
+		for k < len(body) && body[k] != '\\' {
+			k++
+		}
+		if k > 0 {
+			var err error
+			if j.out, _, err = p.pad(j.out, body[:k], true); err != nil && j.err == nil {
+				j.err = err
+			}
+			body = body[k:]
+			continue
+		}
+
+		r, n := decodeEscape(body)
+		j.out = append(j.out, body[:n]...)
+		p.setPrev(r)
+		if r == p.lineEnd() {
+			p.column = 0
+		}
+		body = body[n:]
+	}
+	j.out = append(j.out, '"')
+}
+
+// decodeEscape decodes the escape sequence at the start of b, which is valid,
+// and returns the rune it stands for and its length.
+func decodeEscape(b []byte) (rune, int) {
+	switch b[1] {
+	case 'u':
+		r1, _ := strconv.ParseUint(string(b[2:6]), 16, 16)
+		if utf16.IsSurrogate(rune(r1)) && len(b) >= 12 && b[6] == '\\' && b[7] == 'u' {
+			r2, _ := strconv.ParseUint(string(b[8:12]), 16, 16)
+			if r := utf16.DecodeRune(rune(r1), rune(r2)); r != utf8.RuneError {
+				return r, 12
+			}
+		}
+		return rune(r1), 6
+	case 'b':
+		return '\b', 2
+	case 'f':
+		return '\f', 2
+	case 'n':
+		return '\n', 2
+	case 'r':
+		return '\r', 2
+	case 't':
+		return '\t', 2
+	}
+	return rune(b[1]), 2 // \" \\ \/
+}
+
+// stringEnd returns the index after the string literal starting at data[i].
+func stringEnd(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
@@ -0,0 +1,108 @@
+package problem1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPadJSON(t *testing.T) {
+	doc := `{
+  "title": "Episode 5",
+  "items": [
+    {"id": 7, "name": "part 3", "k2": "v 4"},
+    {"id": 12, "name": "part 10é 1.5"}
+  ],
+  "7": "note 7"
+}`
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{"Wildcard path", []string{"/items/*/name"}, `{
+  "title": "Episode 5",
+  "items": [
+    {"id": 7, "name": "part 003", "k2": "v 4"},
+    {"id": 12, "name": "part 010é 001.5"}
+  ],
+  "7": "note 7"
+}`},
+		{"Several paths", []string{"/title", "/7"}, `{
+  "title": "Episode 005",
+  "items": [
+    {"id": 7, "name": "part 3", "k2": "v 4"},
+    {"id": 12, "name": "part 10é 1.5"}
+  ],
+  "7": "note 007"
+}`},
+		{"Array index", []string{"/items/1/name"}, `{
+  "title": "Episode 5",
+  "items": [
+    {"id": 7, "name": "part 3", "k2": "v 4"},
+    {"id": 12, "name": "part 010é 001.5"}
+  ],
+  "7": "note 7"
+}`},
+		{"All strings", nil, `{
+  "title": "Episode 005",
+  "items": [
+    {"id": 7, "name": "part 003", "k2": "v 004"},
+    {"id": 12, "name": "part 010é 001.5"}
+  ],
+  "7": "note 007"
+}`},
+		{"Numbers are not strings", []string{"/items/*/id"}, doc},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := PadJSON(&out, strings.NewReader(doc), tt.paths, Options{Width: 3}); err != nil {
+				t.Fatalf("PadJSON(%q) error: %v", tt.paths, err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("PadJSON(%q) =\n%s\nwant\n%s", tt.paths, out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadJSONEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// An escaped digit is kept as it is and not padded
+		{`"a\u0031 2"`, `"a\u0031 02"`},
+		// An escaped '.' still makes the next digits a fraction
+		{`"3\u002e5"`, `"03\u002e5"`},
+		{`"x\n5\t6"`, `"x\n05\t06"`},
+		{`"say \"7\""`, `"say \"07\""`},
+		{`"\ud83d\ude00😀 9"`, `"\ud83d\ude00😀 09"`},
+		{`"\/7"`, `"\/07"`},
+		{` [ "1" , 2 ] `, ` [ "01" , 2 ] `},
+	}
+
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := PadJSON(&out, strings.NewReader(tt.input), nil, Options{Width: 2}); err != nil {
+			t.Fatalf("PadJSON(%s) error: %v", tt.input, err)
+		}
+		if out.String() != tt.expected {
+			t.Errorf("PadJSON(%s) = %s; want %s", tt.input, out.String(), tt.expected)
+		}
+	}
+}
+
+func TestPadJSONErrors(t *testing.T) {
+	for _, tt := range []struct{ input, path string }{
+		{`{"a": }`, "/a"},
+		{`{"a": "1"}`, "a"},
+	} {
+		var out strings.Builder
+		if err := PadJSON(&out, strings.NewReader(tt.input), []string{tt.path}, Options{Width: 2}); err == nil {
+			t.Errorf("PadJSON(%s, %q) succeeded; want error", tt.input, tt.path)
+		}
+	}
+}
@@ -0,0 +1,490 @@
+package problem1
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LiteralPolicy decides how a class of numeric literal is padded.
+type LiteralPolicy int
+
+const (
+	// LiteralOff does not recognise the class: its digits are padded as
+	// plain numbers, so "0x1F" is read as the numbers 0 and 1.
+	LiteralOff LiteralPolicy = iota
+	// LiteralSkip copies the literal unchanged.
+	LiteralSkip
+	// LiteralPad pads the literal's leading number only: the digits after a
+	// "0x" prefix, the mantissa of "1e10", the major version of "1.2.3".
+	LiteralPad
+	// LiteralPadEach pads every number in the literal: each component of a
+	// version or IPv4 address, and the exponent of scientific notation.
+	LiteralPadEach
+)
+
+// Literals selects a policy for each class of numeric literal. Literals are
+// only recognised at the start of a word, and must not be followed by a
+// letter, digit or '_' ("0x1Fg" is not a hex literal). The zero value turns
+// every class off, which is what PadNumbers does.
+type Literals struct {
+	Hex    LiteralPolicy // 0x1F, 0X1f
+	Octal  LiteralPolicy // 0o17
+	Binary LiteralPolicy // 0b101
+
+	// Scientific notation: 1e10, 6.02E+23, 1.5e-7. The mantissa uses the
+	// locale's decimal mark.
+	Scientific LiteralPolicy
+
+	// Version matches semantic versions with exactly three components,
+	// optionally after a 'v' and followed by a pre-release or build suffix
+	// ("v1.2.3-rc.1"). The suffix is scanned as ordinary text.
+	Version LiteralPolicy
+
+	// IPv4 matches four dot-separated numbers of at most three digits each,
+	// none above 255.
+	IPv4 LiteralPolicy
+
+	// Ordinal matches English ordinals with the right suffix for their value
+	// ("1st", "22nd", "13th", but not "4st").
+	Ordinal LiteralPolicy
+}
+
+// DefaultLiterals pads the value of hex, octal, binary and scientific
+// literals and of ordinals, and leaves versions and IPv4 addresses alone.
+var DefaultLiterals = Literals{
+	Hex:        LiteralPad,
+	Octal:      LiteralPad,
+	Binary:     LiteralPad,
+	Scientific: LiteralPad,
+	Version:    LiteralSkip,
+	IPv4:       LiteralSkip,
+	Ordinal:    LiteralPad,
+}
+
+// literalClass identifies the kind of a literal token.
+type literalClass int
+
+const (
+	literalHex literalClass = iota
+	literalOctal
+	literalBinary
+	literalScientific
+	literalVersion
+	literalIPv4
+	literalOrdinal
+)
+
+func (l *Literals) policy(class literalClass) LiteralPolicy {
+	switch class {
+	case literalHex:
+		return l.Hex
+	case literalOctal:
+		return l.Octal
+	case literalBinary:
+		return l.Binary
+	case literalScientific:
+		return l.Scientific
+	case literalVersion:
+		return l.Version
+	case literalIPv4:
+		return l.IPv4
+	case literalOrdinal:
+		return l.Ordinal
+	}
+	return LiteralOff
+}
+
+// padded returns how many of the n fields of a literal the policy pads.
+func (pol LiteralPolicy) padded(n int) int {
+	switch pol {
+	case LiteralPad:
+		return min(n, 1)
+	case LiteralPadEach:
+		return n
+	}
+	return 0
+}
+
+// span locates a field of a literal, relative to the start of its text.
+type span struct {
+	start, end int
+}
+
+// maxFields is the largest number of fields in a literal (an IPv4 address).
+const maxFields = 4
+
+// match is the outcome of trying to recognise a literal.
+type match int
+
+const (
+	noMatch  match = iota
+	matched        // the literal ends at the returned offset
+	needMore       // the answer depends on input past the end of src
+)
+
+// literal tries to recognise a literal of an enabled class at src[i], which
+// holds an ASCII digit. On a match it returns the token, with its fields
+// filled in, and the end of the literal in src.
+func (p *padder) literal(src []byte, i int, final bool) (token, int, match) {
+	lits := &p.opts.Literals
+	if *lits == (Literals{}) || !p.literalAllowed() {
+		return token{}, 0, noMatch
+	}
+
+	// Direct calls rather than a table of funcs keep p from escaping.
+	if tok, end, res := p.prefixed(src, i, final); res != noMatch {
+		return tok, end, res
+	}
+	if tok, end, res := p.dotted(src, i, final); res != noMatch {
+		return tok, end, res
+	}
+	if tok, end, res := p.scientific(src, i, final); res != noMatch {
+		return tok, end, res
+	}
+	return p.ordinal(src, i, final)
+}
+
+// literalAllowed reports whether a literal can start after the previous rune,
+// that is at the start of a word.
+func (p *padder) literalAllowed() bool {
+	r := p.prev
+	switch {
+	case r == 0:
+		return true
+	case r == '_', r == '.', r == p.opts.Locale.decimal():
+		return false
+	case (r == 'v' || r == 'V') && !isWordRune(p.prev2):
+		// "v1.2.3" but not "dev1.2.3"; the dotted matcher only accepts
+		// versions after a 'v'
+		return true
+	case unicode.IsLetter(r), unicode.IsDigit(r):
+		return false
+	}
+	return true
+}
+
+// isWordRune reports whether r is a letter or digit, which continues a word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// prefixed matches "0x", "0o" and "0b" literals.
+func (p *padder) prefixed(src []byte, i int, final bool) (token, int, match) {
+	if src[i] != '0' || p.afterV() {
+		return token{}, 0, noMatch
+	}
+	if i+1 == len(src) {
+		return token{}, 0, more(final)
+	}
+
+	var class literalClass
+	var isDigit func(byte) bool
+	switch src[i+1] | 0x20 { // lower case
+	case 'x':
+		class, isDigit = literalHex, isHexDigit
+	case 'o':
+		class, isDigit = literalOctal, isOctalDigit
+	case 'b':
+		class, isDigit = literalBinary, isBinaryDigit
+	default:
+		return token{}, 0, noMatch
+	}
+	if p.opts.Literals.policy(class) == LiteralOff {
+		return token{}, 0, noMatch
+	}
+
+	end := asciiRun(src, i+2, isDigit)
+	if end == len(src) && !final {
+		return token{}, 0, needMore
+	}
+	if end == i+2 {
+		return token{}, 0, noMatch
+	}
+	tok := token{kind: tokenLiteral, class: class, nfields: 1}
+	tok.fields[0] = span{2, end - i}
+	return p.finishLiteral(src, i, end, tok, final)
+}
+
+// dotted matches versions and IPv4 addresses.
+func (p *padder) dotted(src []byte, i int, final bool) (token, int, match) {
+	lits := &p.opts.Literals
+	if lits.Version == LiteralOff && lits.IPv4 == LiteralOff || p.opts.Locale.Grouping == '.' {
+		return token{}, 0, noMatch
+	}
+
+	var fields [maxFields]span
+	n := 0
+	j := i
+	for {
+		end := asciiRun(src, j, isDecimalDigit)
+		if end == len(src) && !final {
+			return token{}, 0, needMore
+		}
+		if n == maxFields {
+			return token{}, 0, noMatch
+		}
+		fields[n] = span{j - i, end - i}
+		n++
+
+		if end == len(src) || src[end] != '.' {
+			j = end
+			break
+		}
+		if end+1 == len(src) {
+			if !final {
+				return token{}, 0, needMore
+			}
+			j = end
+			break
+		}
+		if !isDecimalDigit(src[end+1]) {
+			j = end
+			break
+		}
+		j = end + 1
+	}
+
+	var class literalClass
+	switch {
+	case n == 3 && lits.Version != LiteralOff:
+		class = literalVersion
+	case n == 4 && lits.IPv4 != LiteralOff && !p.afterV() && isIPv4(src[i:], fields[:n]):
+		class = literalIPv4
+	default:
+		return token{}, 0, noMatch
+	}
+
+	tok := token{kind: tokenLiteral, class: class, fields: fields, nfields: n}
+	return p.finishLiteral(src, i, j, tok, final)
+}
+
+// scientific matches decimal numbers with an exponent.
+func (p *padder) scientific(src []byte, i int, final bool) (token, int, match) {
+	if p.opts.Literals.Scientific == LiteralOff || p.afterV() {
+		return token{}, 0, noMatch
+	}
+
+	end := asciiRun(src, i, isDecimalDigit)
+	mantissa := span{0, end - i}
+
+	var buf [utf8.UTFMax]byte
+	mark := buf[:utf8.EncodeRune(buf[:], p.opts.Locale.decimal())]
+	if res := hasPrefixAt(src, end, mark, final); res == needMore {
+		return token{}, 0, needMore
+	} else if res == matched {
+		fraction := asciiRun(src, end+len(mark), isDecimalDigit)
+		if fraction == len(src) && !final {
+			return token{}, 0, needMore
+		}
+		if fraction == end+len(mark) {
+			return token{}, 0, noMatch
+		}
+		end = fraction
+	}
+
+	if end == len(src) {
+		return token{}, 0, more(final)
+	}
+	if src[end]|0x20 != 'e' {
+		return token{}, 0, noMatch
+	}
+	j := end + 1
+	if j < len(src) && isSign(src[j]) {
+		j++
+	}
+	exp := asciiRun(src, j, isDecimalDigit)
+	if exp == len(src) && !final {
+		return token{}, 0, needMore
+	}
+	if exp == j {
+		return token{}, 0, noMatch
+	}
+
+	tok := token{kind: tokenLiteral, class: literalScientific, nfields: 2}
+	tok.fields[0] = mantissa
+	tok.fields[1] = span{j - i, exp - i}
+	return p.finishLiteral(src, i, exp, tok, final)
+}
+
+// ordinal matches "1st", "2nd", "3rd", "4th" and so on.
+func (p *padder) ordinal(src []byte, i int, final bool) (token, int, match) {
+	if p.opts.Literals.Ordinal == LiteralOff || p.afterV() {
+		return token{}, 0, noMatch
+	}
+
+	end := asciiRun(src, i, isDecimalDigit)
+	if end < len(src) && !isASCIILetter(src[end]) {
+		return token{}, 0, noMatch
+	}
+	if len(src)-end < 2 {
+		if !final {
+			return token{}, 0, needMore
+		}
+		return token{}, 0, noMatch
+	}
+
+	suffix := [2]byte{src[end] | 0x20, src[end+1] | 0x20}
+	if suffix != ordinalSuffix(src[i:end]) {
+		return token{}, 0, noMatch
+	}
+
+	tok := token{kind: tokenLiteral, class: literalOrdinal, nfields: 1}
+	tok.fields[0] = span{0, end - i}
+	return p.finishLiteral(src, i, end+2, tok, final)
+}
+
+// finishLiteral checks that the literal src[i:end] ends a word and fills in
+// the rest of tok.
+func (p *padder) finishLiteral(src []byte, i, end int, tok token, final bool) (token, int, match) {
+	if end == len(src) {
+		if !final {
+			return token{}, 0, needMore
+		}
+	} else {
+		if !final && !utf8.FullRune(src[end:]) {
+			return token{}, 0, needMore
+		}
+		r, _ := utf8.DecodeRune(src[end:])
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return token{}, 0, noMatch
+		}
+	}
+
+	tok.text = src[i:end]
+	tok.zero = '0'
+	return tok, end, matched
+}
+
+// afterV reports whether the previous rune is a 'v', which only versions may
+// follow.
+func (p *padder) afterV() bool {
+	return p.prev == 'v' || p.prev == 'V'
+}
+
+// ordinalSuffix returns the English ordinal suffix for the decimal number
+// digits.
+func ordinalSuffix(digits []byte) [2]byte {
+	last := digits[len(digits)-1]
+	if len(digits) > 1 && digits[len(digits)-2] == '1' {
+		return [2]byte{'t', 'h'} // 11th, 12th, 13th
+	}
+	switch last {
+	case '1':
+		return [2]byte{'s', 't'}
+	case '2':
+		return [2]byte{'n', 'd'}
+	case '3':
+		return [2]byte{'r', 'd'}
+	}
+	return [2]byte{'t', 'h'}
+}
+
+func isIPv4(text []byte, fields []span) bool {
+	for _, f := range fields {
+		if f.end-f.start > 3 {
+			return false
+		}
+		v := 0
+		for _, c := range text[f.start:f.end] {
+			v = v*10 + int(c-'0')
+		}
+		if v > 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendLiteral appends the literal tok, padding the fields its class's
+// policy selects to width. The sign and any prefix are not part of a field;
+// the sign counts toward width only when the field starts the literal.
+func (o *Options) appendLiteral(dst []byte, width int, tok token) ([]byte, error) {
+	n := o.Literals.policy(tok.class).padded(tok.nfields)
+	if n == 0 {
+		return appendSigned(dst, tok.sign, tok.text), nil
+	}
+
+	sign := tok.sign
+	if tok.fields[0].start > 0 && sign != 0 {
+		dst = append(dst, sign)
+		sign = 0
+	}
+
+	last := 0
+	for _, f := range tok.fields[:n] {
+		dst = append(dst, tok.text[last:f.start]...)
+		var err error
+		dst, err = o.appendNumber(dst, width, sign, tok.text[f.start:f.end], tok.zero)
+		if err != nil {
+			return dst, err
+		}
+		sign = 0
+		last = f.end
+	}
+	return append(dst, tok.text[last:]...), nil
+}
+
+// literalWidth returns the width of the widest field of tok that is padded,
+// counting the sign as appendLiteral does.
+func (o *Options) literalWidth(tok token) int {
+	width := 0
+	for k, f := range tok.fields[:o.Literals.policy(tok.class).padded(tok.nfields)] {
+		w := f.end - f.start
+		if k == 0 && f.start == 0 && tok.sign != 0 {
+			w++
+		}
+		width = max(width, w)
+	}
+	return width
+}
+
+// asciiRun returns the end of the run of bytes accepted by ok from src[i].
+func asciiRun(src []byte, i int, ok func(byte) bool) int {
+	for i < len(src) && ok(src[i]) {
+		i++
+	}
+	return i
+}
+
+// hasPrefixAt reports whether src[i:] starts with prefix, or needMore if
+// src ends inside a possible prefix and final is not set.
+func hasPrefixAt(src []byte, i int, prefix []byte, final bool) match {
+	rest := src[i:]
+	if bytes.HasPrefix(rest, prefix) {
+		return matched
+	}
+	if !final && len(rest) < len(prefix) && bytes.HasPrefix(prefix, rest) {
+		return needMore
+	}
+	return noMatch
+}
+
+// more is the result of reaching the end of src before a literal could be
+// told apart from a plain number.
+func more(final bool) match {
+	if final {
+		return noMatch
+	}
+	return needMore
+}
+
+func isDecimalDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDecimalDigit(c) || 'a' <= c|0x20 && c|0x20 <= 'f'
+}
+
+func isOctalDigit(c byte) bool {
+	return '0' <= c && c <= '7'
+}
+
+func isASCIILetter(c byte) bool {
+	return 'a' <= c|0x20 && c|0x20 <= 'z'
+}
+
+func isBinaryDigit(c byte) bool {
+	return c == '0' || c == '1'
+}
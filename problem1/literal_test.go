@@ -0,0 +1,118 @@
+package problem1
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestPadNumbersLiterals(t *testing.T) {
+	defaults := Options{Width: 4, Literals: DefaultLiterals}
+	each := Options{Width: 3, Literals: Literals{
+		Hex: LiteralPadEach, Scientific: LiteralPadEach, Version: LiteralPadEach,
+		IPv4: LiteralPadEach, Ordinal: LiteralPadEach,
+	}}
+	skip := Options{Width: 3, Literals: Literals{
+		Hex: LiteralSkip, Octal: LiteralSkip, Binary: LiteralSkip, Scientific: LiteralSkip,
+		Version: LiteralSkip, IPv4: LiteralSkip, Ordinal: LiteralSkip,
+	}}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		// Without a classifier every digit run is a number
+		{"Off hex", "0x1F", Options{Width: 3}, "000x001F"},
+		{"Off scientific", "1e10", Options{Width: 3}, "001e010"},
+		{"Off version", "1.2.3", Options{Width: 3}, "001.2.3"},
+
+		{"Hex", "mask 0x1F", defaults, "mask 0x001F"},
+		{"Hex upper-case prefix", "0XfF", defaults, "0X00fF"},
+		{"Octal", "mode 0o755", defaults, "mode 0o0755"},
+		{"Binary", "0b101,0b1", defaults, "0b0101,0b0001"},
+		{"Hex needs digits", "0x", defaults, "0000x"},
+		{"Hex followed by letter", "0x1Fg", defaults, "0000x0001Fg"},
+		{"Binary followed by digit", "0b12", defaults, "0000b0012"},
+		{"Prefix inside word", "a0x1", defaults, "a0000x0001"},
+		{"Hex strips zeros", "0x00F", Options{Width: 2, Literals: DefaultLiterals, LeadingZeros: LeadingZerosIgnore}, "0x0F"},
+		{"Hex truncates", "0xABCDE", Options{Width: 3, Literals: DefaultLiterals, Mode: WidthExact, Overflow: OverflowTruncate}, "0xCDE"},
+
+		{"Scientific", "1e10", defaults, "0001e10"},
+		{"Scientific fraction", "6.02E+23 mol", defaults, "0006.02E+23 mol"},
+		{"Scientific negative exponent", "1.5e-7", defaults, "0001.5e-7"},
+		{"Scientific pad each", "1.5e-7", each, "001.5e-007"},
+		{"Scientific signed", "x -2e5", Options{Width: 4, Literals: DefaultLiterals, Signed: true}, "x -002e5"},
+		{"Scientific needs exponent", "1e", defaults, "0001e"},
+		{"Scientific in word", "2em", defaults, "0002em"},
+		{"Scientific decimal comma", "1,5e3", Options{Width: 3, Locale: LocaleDE, Literals: DefaultLiterals}, "001,5e3"},
+
+		{"Version skipped", "go 1.22.3 ok", defaults, "go 1.22.3 ok"},
+		{"Version with v", "v1.2.3-rc.1", defaults, "v1.2.3-rc.1"},
+		{"Version pad each", "v1.2.13", each, "v001.002.013"},
+		{"Version after v in word", "dev1.2.3 nav1.2.3", each, "dev001.2.3 nav001.2.3"},
+		{"Version after v after punctuation", "(v1.2.3)", each, "(v001.002.003)"},
+		{"Version pad", "1.2.3", Options{Width: 3, Literals: Literals{Version: LiteralPad}}, "001.2.3"},
+		{"Version at sentence end", "see 1.2.3.", each, "see 001.002.003."},
+		{"Two components", "v1.2", each, "v001.2"},
+		{"Five components", "1.2.3.4.5", each, "001.2.3.4.5"},
+
+		{"IPv4 skipped", "host 192.168.0.1:80", defaults, "host 192.168.0.1:0080"},
+		{"IPv4 pad each", "10.0.0.1", each, "010.000.000.001"},
+		{"IPv4 out of range", "1.2.3.256", Options{Width: 3, Literals: Literals{IPv4: LiteralPadEach}}, "001.2.3.256"},
+		{"IPv4 after v", "v1.2.3.4", each, "v001.2.3.4"},
+
+		{"Ordinal", "the 1st and 22nd", defaults, "the 0001st and 0022nd"},
+		{"Ordinal skipped", "3rd 11th 4th", skip, "3rd 11th 4th"},
+		{"Ordinal wrong suffix", "4st", skip, "004st"},
+		{"Ordinal teens", "12nd 12th", skip, "012nd 12th"},
+		{"Ordinal upper case", "1ST", skip, "1ST"},
+		{"Ordinal in word", "1sts", skip, "001sts"},
+
+		{"Skip all", "0x1 0o7 0b1 1e3 1.2.3 1.2.3.4 2nd", skip, "0x1 0o7 0b1 1e3 1.2.3 1.2.3.4 2nd"},
+		{"Plain numbers unchanged", "a 7 b 3.14", defaults, "a 0007 b 0003.14"},
+		{"Grouping dot disables dotted", "1.2.3", Options{Width: 3, Locale: LocaleDE, Literals: DefaultLiterals}, "001.002.003"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PadNumbersWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersWithOptions(%q) error: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("PadNumbersWithOptions(%q, %+v) = %q; want %q",
+					tt.input, tt.opts.Literals, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadNumbersLiteralsOverflowError(t *testing.T) {
+	opts := Options{Width: 2, Mode: WidthExact, Overflow: OverflowError, Literals: DefaultLiterals}
+	if _, err := PadNumbersWithOptions("0x1FF", opts); !errors.Is(err, ErrOverflow) {
+		t.Errorf("error = %v; want ErrOverflow", err)
+	}
+}
+
+func TestPadStreamAutoLiterals(t *testing.T) {
+	opts := Options{Literals: Literals{IPv4: LiteralPadEach}}
+	input := "10.0.0.1 a\n192.168.1.20 b\n"
+	want := "010.000.000.001 a\n192.168.001.020 b\n"
+
+	var buf bytes.Buffer
+	if err := PadStreamAuto(&buf, bytes.NewReader([]byte(input)), opts, false); err != nil {
+		t.Fatalf("PadStreamAuto() error: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("PadStreamAuto(%q) = %q; want %q", input, buf.String(), want)
+	}
+}
+
+func TestPadWriterLiteralsAtChunkBoundary(t *testing.T) {
+	input := "0x1F 6.02e+23 v1.2.3-rc.1 10.0.0.1. 22nd 0b102 1.2.3.4.5 7"
+	for _, lits := range []Literals{DefaultLiterals, {Version: LiteralPadEach, IPv4: LiteralPadEach, Scientific: LiteralPadEach}} {
+		checkSplits(t, input, Options{Width: 4, Literals: lits, Signed: true})
+	}
+}
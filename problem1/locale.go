@@ -0,0 +1,37 @@
+package problem1
+
+// Locale describes how numbers are written: which mark separates the
+// fraction and which one groups the digits of the whole part. The zero value
+// uses '.' as the decimal mark and no grouping, which is what PadNumbers
+// does.
+type Locale struct {
+	Decimal   rune // decimal mark, '.' if zero
+	Grouping  rune // thousands separator, 0 disables grouping
+	GroupSize int  // digits per group, 3 if zero
+}
+
+// Presets for common locales. Any other convention can be described with a
+// custom Locale value.
+var (
+	// LocaleEN writes 1,234,567.89
+	LocaleEN = Locale{Decimal: '.', Grouping: ',', GroupSize: 3}
+	// LocaleDE writes 1.234.567,89
+	LocaleDE = Locale{Decimal: ',', Grouping: '.', GroupSize: 3}
+	// LocaleFR writes 1 234 567,89 with a narrow no-break space (U+202F)
+	// between groups.
+	LocaleFR = Locale{Decimal: ',', Grouping: ' ', GroupSize: 3}
+)
+
+func (l Locale) decimal() rune {
+	if l.Decimal == 0 {
+		return '.'
+	}
+	return l.Decimal
+}
+
+func (l Locale) groupSize() int {
+	if l.GroupSize <= 0 {
+		return 3
+	}
+	return l.GroupSize
+}
@@ -0,0 +1,99 @@
+package problem1
+
+import "testing"
+
+func TestPadNumbersLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "Default locale does not group",
+			input:    "1,000,000",
+			opts:     Options{Width: 4},
+			expected: "0001,0000,0000",
+		},
+		{
+			name:     "English grouping",
+			input:    "Total: 1,000,000 units",
+			opts:     Options{Width: 11, Locale: LocaleEN},
+			expected: "Total: 001,000,000 units",
+		},
+		{
+			name:     "English grouped decimal",
+			input:    "1,234.5",
+			opts:     Options{Width: 6, Locale: LocaleEN},
+			expected: "01,234.5",
+		},
+		{
+			name:     "Invalid groups are separate numbers",
+			input:    "1,2,3 and 12,3456",
+			opts:     Options{Width: 2, Locale: LocaleEN},
+			expected: "01,02,03 and 12,3456",
+		},
+		{
+			name:     "Leading group too long",
+			input:    "12345,678",
+			opts:     Options{Width: 4, Locale: LocaleEN},
+			expected: "12345,0678",
+		},
+		{
+			name:     "German decimal comma",
+			input:    "Pi ist 3,14",
+			opts:     Options{Width: 2, Locale: LocaleDE},
+			expected: "Pi ist 03,14",
+		},
+		{
+			name:     "German grouping and decimal",
+			input:    "1.234.567,89 EUR",
+			opts:     Options{Width: 10, Locale: LocaleDE},
+			expected: "01.234.567,89 EUR",
+		},
+		{
+			name:     "German dot is not a decimal mark",
+			input:    "3.14",
+			opts:     Options{Width: 2, Locale: LocaleDE},
+			expected: "03.14",
+		},
+		{
+			name:     "French narrow no-break space grouping",
+			input:    "1 234,5",
+			opts:     Options{Width: 6, Locale: LocaleFR},
+			expected: "01 234,5",
+		},
+		{
+			name:     "Custom locale",
+			input:    "12'34'56",
+			opts:     Options{Width: 9, Locale: Locale{Decimal: '.', Grouping: '\'', GroupSize: 2}},
+			expected: "012'34'56",
+		},
+		{
+			name:     "Truncation drops a leading grouping mark",
+			input:    "1,234,567",
+			opts:     Options{Width: 4, Locale: LocaleEN, Mode: WidthExact, Overflow: OverflowTruncate},
+			expected: "0567",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PadNumbersWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersWithOptions(%q, %+v) unexpected error: %v", tt.input, tt.opts, err)
+			}
+			if result != tt.expected {
+				t.Errorf("PadNumbersWithOptions(%q, %+v) = %q; want %q",
+					tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadWriterLocaleAtChunkBoundary(t *testing.T) {
+	for _, loc := range []Locale{LocaleEN, LocaleDE, LocaleFR} {
+		input := "a 1,000,000.5 b 2.000,25 c 3 000 d 12,34"
+		checkSplits(t, input, Options{Width: 8, Locale: loc})
+	}
+}
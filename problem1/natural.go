@@ -0,0 +1,108 @@
+package problem1
+
+import "unicode/utf8"
+
+// NaturalLess reports whether a sorts before b in natural order, where
+// numbers are compared by value instead of character by character, so that
+// "file2" comes before "file10". It splits its inputs with the same scanner
+// as PadNumbers:
+//
+//   - whole numbers compare by value, in any Unicode digit script, and
+//     sort where the digit '0' would among the surrounding text;
+//   - digits after a '.' compare as a decimal fraction ("1.5" > "1.25");
+//   - other text compares by code point.
+//
+// Strings that are equal in value ("7" and "007") are ordered by the number
+// of leading zeros, fewest first, and finally byte by byte, so the order is
+// total. NaturalLess(a, b) is equivalent to comparing NaturalSortKey(a) and
+// NaturalSortKey(b).
+func NaturalLess(a, b string) bool {
+	return NaturalSortKey(a) < NaturalSortKey(b)
+}
+
+// NaturalSortKey returns a key for s whose byte order is the natural order
+// described at NaturalLess. It is cheaper to compute keys once and compare
+// them than to call NaturalLess repeatedly when sorting large inputs.
+func NaturalSortKey(s string) string {
+	src := []byte(s)
+	p := padder{}
+
+	key := make([]byte, 0, len(s)*2+2)
+	var zeros []byte // leading zero counts, the first tie-breaker
+
+	for i := 0; i < len(src); {
+		tok, n, _ := p.next(src[i:], true)
+		i += n
+
+		switch tok.kind {
+		case tokenWhole:
+			digits := tok.text
+			skipped := 0
+			for len(digits) > 0 {
+				r, size, zero := decodeDigit(digits)
+				if r != zero {
+					break
+				}
+				digits = digits[size:]
+				skipped++
+			}
+			key = append(key, '0')
+			key = appendSortLength(key, utf8.RuneCount(digits))
+			key = appendDigitValues(key, digits)
+			zeros = appendSortLength(zeros, skipped)
+		case tokenFraction:
+			key = appendDigitValues(key, tok.text)
+		default:
+			key = appendEscaped(key, tok.text)
+		}
+	}
+
+	// 0x00 never occurs in the escaped primary key, so a key that is a
+	// prefix of another sorts first.
+	key = append(key, 0)
+	key = append(key, zeros...)
+	key = append(key, 0)
+	key = append(key, s...)
+	return string(key)
+}
+
+// appendSortLength appends n so that larger values compare greater byte by
+// byte: one more than the number of its base-255 digits, followed by the
+// digits, each plus one. No byte of it is 0x00, not even for n = 0.
+func appendSortLength(dst []byte, n int) []byte {
+	var buf [8]byte // 255^8 > math.MaxInt64
+	k := len(buf)
+	for ; n > 0; n /= 255 {
+		k--
+		buf[k] = byte(n%255) + 1
+	}
+	dst = append(dst, byte(len(buf)-k+1))
+	return append(dst, buf[k:]...)
+}
+
+// appendDigitValues appends the value of every digit in b as an ASCII digit,
+// so digits of any script compare by value.
+func appendDigitValues(dst, b []byte) []byte {
+	for len(b) > 0 {
+		r, size, zero := decodeDigit(b)
+		if zero >= 0 {
+			dst = append(dst, byte('0'+r-zero))
+		}
+		// Grouping marks inside a number carry no value
+		b = b[size:]
+	}
+	return dst
+}
+
+// appendEscaped appends text so that it never contains 0x00: 0x00 and 0x01
+// become 0x01 0x01 and 0x01 0x02, which keeps the byte order intact.
+func appendEscaped(dst, text []byte) []byte {
+	for _, c := range text {
+		if c <= 1 {
+			dst = append(dst, 1, c+1)
+			continue
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
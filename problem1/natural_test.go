@@ -0,0 +1,106 @@
+package problem1
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		less bool
+	}{
+		{"Numbers by value", "file2", "file10", true},
+		{"Numbers by value reversed", "file10", "file2", false},
+		{"Equal strings", "file2", "file2", false},
+		{"Prefix first", "file", "file1", true},
+		{"Decimal fraction", "v1.25", "v1.5", true},
+		{"Whole part before fraction", "9.99", "10.01", true},
+		{"Fewer leading zeros first", "img7", "img007", true},
+		{"Value before leading zeros", "img007", "img8", true},
+		{"Long numbers", "n9223372036854775808", "n18446744073709551616", true},
+		{"Number sorts as digit", "a 1", "a1", true},
+		{"Number before letters", "a1", "ab", true},
+		{"Full-width digits by value", "第２話", "第１０話", true},
+		{"Scripts with equal value", "７", "8", true},
+		{"Unicode text by code point", "éa", "éb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NaturalLess(tt.a, tt.b); got != tt.less {
+				t.Errorf("NaturalLess(%q, %q) = %v; want %v", tt.a, tt.b, got, tt.less)
+			}
+		})
+	}
+}
+
+func TestNaturalSortKey(t *testing.T) {
+	want := []string{
+		"",
+		"file",
+		"file0",
+		"file00",
+		"file0a",
+		"file1",
+		"file01",
+		"file1.5",
+		"file2",
+		"file2.05",
+		"file2.5",
+		"file10",
+		"file010",
+		"file100",
+		"file١٠١",
+		"fileA",
+	}
+
+	got := slices.Clone(want)
+	slices.Reverse(got)
+	slices.SortFunc(got, func(a, b string) int {
+		ka, kb := NaturalSortKey(a), NaturalSortKey(b)
+		switch {
+		case ka < kb:
+			return -1
+		case ka > kb:
+			return 1
+		}
+		return 0
+	})
+
+	if !slices.Equal(got, want) {
+		t.Errorf("natural sort = %q; want %q", got, want)
+	}
+}
+
+func TestNaturalSortKeyTotalOrder(t *testing.T) {
+	// Different strings must never get the same key.
+	inputs := []string{"7", "07", "007", "７", "1,000", "1000", "a\x00b", "a\x01b", "ab"}
+	seen := make(map[string]string)
+	for _, s := range inputs {
+		key := NaturalSortKey(s)
+		if other, ok := seen[key]; ok {
+			t.Errorf("NaturalSortKey(%q) == NaturalSortKey(%q)", s, other)
+		}
+		seen[key] = s
+	}
+}
+
+func TestAppendSortLength(t *testing.T) {
+	// Zero, and values whose base-255 digits would include 0x00 when
+	// written as plain bytes, must encode without 0x00 and in order.
+	var prev []byte
+	for k, n := range []int{0, 1, 254, 255, 256, 510, 65025, 65536, 1 << 40, math.MaxInt} {
+		got := appendSortLength(nil, n)
+		if bytes.IndexByte(got, 0) >= 0 {
+			t.Errorf("appendSortLength(%d) = %x; contains 0x00", n, got)
+		}
+		if k > 0 && bytes.Compare(prev, got) >= 0 {
+			t.Errorf("appendSortLength(%d) = %x; not above the previous %x", n, got, prev)
+		}
+		prev = got
+	}
+}
@@ -0,0 +1,231 @@
+package problem1
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Alignment selects on which side of a number the padding goes.
+type Alignment int
+
+const (
+	// AlignRight puts padding before the digits ("7" -> "007").
+	AlignRight Alignment = iota
+	// AlignLeft puts padding after the digits ("7" -> "7  "). Zeros after
+	// the digits would change the number's value, so zero padding becomes
+	// spaces.
+	AlignLeft
+)
+
+// WidthMode selects how Width is interpreted.
+type WidthMode int
+
+const (
+	// WidthMin pads short numbers and leaves longer ones unchanged.
+	WidthMin WidthMode = iota
+	// WidthExact makes every number exactly Width characters wide; numbers
+	// that are already wider are handled according to the Overflow policy.
+	WidthExact
+)
+
+// OverflowPolicy decides what happens to a number wider than Width in
+// WidthExact mode.
+type OverflowPolicy int
+
+const (
+	// OverflowLeave keeps the number unchanged.
+	OverflowLeave OverflowPolicy = iota
+	// OverflowTruncate keeps only the rightmost Width digits.
+	OverflowTruncate
+	// OverflowError aborts padding with an error wrapping ErrOverflow.
+	OverflowError
+)
+
+// LeadingZeroPolicy decides whether zeros already in front of a number count
+// toward Width.
+type LeadingZeroPolicy int
+
+const (
+	// LeadingZerosCount keeps existing leading zeros as digits of the number
+	// ("0007" stays "0007" for width 2).
+	LeadingZerosCount LeadingZeroPolicy = iota
+	// LeadingZerosIgnore treats existing leading zeros as padding and strips
+	// them before padding again ("0007" -> "07" for width 2). A number made
+	// only of zeros keeps a single "0".
+	LeadingZerosIgnore
+)
+
+// ErrOverflow is returned when a number does not fit in the requested width
+// and the OverflowError policy is in effect.
+var ErrOverflow = errors.New("number exceeds width")
+
+// Options configures PadNumbersWithOptions. The zero value of every field
+// except Width matches PadNumbers.
+type Options struct {
+	// Width is the width numbers are padded to. As with fmt's '-' flag, a
+	// negative Width pads to its absolute value with AlignLeft, so
+	// PadNumbers left-justifies with spaces like the "%0*d" verb.
+	Width    int
+	PadChar  rune // defaults to '0', the zero of the number's script; ' ' with AlignLeft
+	Align    Alignment
+	Mode     WidthMode
+	Overflow OverflowPolicy
+
+	LeadingZeros LeadingZeroPolicy
+
+	// Signed recognises a '+' or '-' directly in front of a number as its
+	// sign when it follows whitespace, punctuation or the start of input.
+	// The sign then counts toward Width ("-7" -> "-07" for width 3).
+	// Hyphens after letters or digits ("ID-7", "2024-1-5") stay separators.
+	Signed bool
+
+	// Locale sets the decimal and grouping marks. With grouping enabled,
+	// "1,000,000" is padded as one number; digits after the decimal mark are
+	// never padded.
+	Locale Locale
+
+	// Precision, when positive, normalizes the fraction of every whole
+	// number that has one to this many digits. Shorter fractions are padded
+	// with zeros on the right ("3.1" -> "03.100" for width 2 and precision
+	// 3), longer ones are handled according to Fraction. The fraction does
+	// not count toward Width. Numbers without a fraction never get one, and
+	// digits after a mark that does not follow a whole number (".5", the
+	// third part of "1.2.3") are left as they are.
+	Precision int
+	Fraction  FractionPolicy
+
+	// JoinMixedScripts treats adjacent digits from different scripts
+	// ("１2") as one number. By default each script starts a new number.
+	JoinMixedScripts bool
+
+	// Literals recognises hex, octal and binary literals, scientific
+	// notation, versions, IPv4 addresses and ordinals, and pads each class
+	// according to its policy. The zero value recognises none of them.
+	Literals Literals
+
+	// NullSeparated means lines end with a NUL byte instead of '\n', as in
+	// "find -print0" output. It only matters where numbers are counted per
+	// line, such as PadStreamAuto with perColumn.
+	NullSeparated bool
+}
+
+// PadNumbersWithOptions pads whole numbers in input according to opts. It
+// returns an error only when opts.Overflow is OverflowError and a number is
+// wider than opts.Width in WidthExact mode.
+func PadNumbersWithOptions(input string, opts Options) (string, error) {
+	p := padder{opts: opts}
+	out, _, err := p.pad(make([]byte, 0, len(input)), []byte(input), true)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// appendNumber appends the number num, preceded by sign when it is not zero,
+// padded to width and otherwise formatted according to opts. The width is
+// measured in characters, so a sign and any grouping marks inside num count
+// toward it. The digits are copied as text, so runs of any length are
+// preserved exactly. zero is the zero digit of the number's script, used for
+// zero padding.
+func (o *Options) appendNumber(dst []byte, width int, sign byte, num []byte, zero rune) ([]byte, error) {
+	digits := o.significant(num)
+
+	fieldWidth := width
+	if sign != 0 {
+		width--
+	}
+
+	n := utf8.RuneCount(digits)
+	if n > width {
+		if o.Mode != WidthExact || width <= 0 {
+			return appendSigned(dst, sign, digits), nil
+		}
+		switch o.Overflow {
+		case OverflowTruncate:
+			// Drop characters from the left, then any grouping mark that
+			// would be left in front.
+			for n > width || o.startsWithGrouping(digits) {
+				_, size := utf8.DecodeRune(digits)
+				digits = digits[size:]
+				n--
+			}
+		case OverflowError:
+			return dst, fmt.Errorf("%w: %s%s is wider than %d", ErrOverflow, signString(sign), num, fieldWidth)
+		default:
+			return appendSigned(dst, sign, digits), nil
+		}
+	}
+
+	padChar := o.PadChar
+	if padChar == 0 || padChar == '0' {
+		// Zero padding uses the zero of the number's own script.
+		padChar = zero
+	}
+
+	if o.Align == AlignLeft {
+		if padChar == zero {
+			padChar = ' '
+		}
+		dst = appendSigned(dst, sign, digits)
+		return appendPadding(dst, padChar, width-n), nil
+	}
+
+	// Zeros go between the sign and the digits ("-007"), any other pad
+	// character goes in front of the sign ("  -7").
+	if padChar == zero {
+		if sign != 0 {
+			dst = append(dst, sign)
+		}
+		dst = appendPadding(dst, padChar, width-n)
+		return append(dst, digits...), nil
+	}
+	dst = appendPadding(dst, padChar, width-n)
+	return appendSigned(dst, sign, digits), nil
+}
+
+// significant returns num without the leading zeros that the LeadingZeros
+// policy treats as padding.
+func (o *Options) significant(num []byte) []byte {
+	if o.LeadingZeros != LeadingZerosIgnore {
+		return num
+	}
+	for {
+		r, size, z := decodeDigit(num)
+		if r != z || size == len(num) || o.startsWithGrouping(num[size:]) {
+			return num
+		}
+		num = num[size:]
+	}
+}
+
+// startsWithGrouping reports whether b starts with the locale's grouping
+// mark, the only character in a number that is not one of its digits.
+func (o *Options) startsWithGrouping(b []byte) bool {
+	if o.Locale.Grouping == 0 {
+		return false
+	}
+	r, _ := utf8.DecodeRune(b)
+	return r == o.Locale.Grouping
+}
+
+func appendSigned(dst []byte, sign byte, digits []byte) []byte {
+	if sign != 0 {
+		dst = append(dst, sign)
+	}
+	return append(dst, digits...)
+}
+
+func appendPadding(dst []byte, padChar rune, n int) []byte {
+	for ; n > 0; n-- {
+		dst = utf8.AppendRune(dst, padChar)
+	}
+	return dst
+}
+
+func signString(sign byte) string {
+	if sign == 0 {
+		return ""
+	}
+	return string(sign)
+}
@@ -0,0 +1,239 @@
+package problem1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPadNumbersWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "Defaults match PadNumbers",
+			input:    "PI=3.14 and 99UR1337",
+			opts:     Options{Width: 6},
+			expected: "PI=000003.14 and 000099UR001337",
+		},
+		{
+			name:     "Space padding",
+			input:    "Item 7 costs 42",
+			opts:     Options{Width: 4, PadChar: ' '},
+			expected: "Item    7 costs   42",
+		},
+		{
+			name:     "Underscore padding",
+			input:    "v5",
+			opts:     Options{Width: 3, PadChar: '_'},
+			expected: "v__5",
+		},
+		{
+			name:     "Multibyte pad rune",
+			input:    "7",
+			opts:     Options{Width: 3, PadChar: '·'},
+			expected: "··7",
+		},
+		{
+			name:     "Left alignment",
+			input:    "[7] [42]",
+			opts:     Options{Width: 4, PadChar: ' ', Align: AlignLeft},
+			expected: "[7   ] [42  ]",
+		},
+		{
+			name:     "Negative width aligns left",
+			input:    "[7] [42] [-7]",
+			opts:     Options{Width: -4, Signed: true},
+			expected: "[7   ] [42  ] [-7  ]",
+		},
+		{
+			name:     "Negative width keeps pad character",
+			input:    "[7]",
+			opts:     Options{Width: -3, PadChar: '_'},
+			expected: "[7__]",
+		},
+		{
+			name:     "Left alignment never pads with zeros",
+			input:    "[7] [-7] [٧]",
+			opts:     Options{Width: 3, Align: AlignLeft, Signed: true},
+			expected: "[7  ] [-7 ] [٧  ]",
+		},
+		{
+			name:     "Left alignment with explicit zero pad",
+			input:    "[7]",
+			opts:     Options{Width: 3, PadChar: '0', Align: AlignLeft},
+			expected: "[7  ]",
+		},
+		{
+			name:     "Decimal fraction untouched",
+			input:    "3.14",
+			opts:     Options{Width: 3, PadChar: ' '},
+			expected: "  3.14",
+		},
+		{
+			name:     "Minimum width leaves wide numbers",
+			input:    "Year 2024",
+			opts:     Options{Width: 2, Overflow: OverflowTruncate},
+			expected: "Year 2024",
+		},
+		{
+			name:     "Exact width leaves wide numbers",
+			input:    "Year 2024",
+			opts:     Options{Width: 2, Mode: WidthExact},
+			expected: "Year 2024",
+		},
+		{
+			name:     "Exact width truncates from the left",
+			input:    "Year 2024, day 5",
+			opts:     Options{Width: 2, Mode: WidthExact, Overflow: OverflowTruncate},
+			expected: "Year 24, day 05",
+		},
+		{
+			name:     "Leading zeros counted by default",
+			input:    "0007 and 007",
+			opts:     Options{Width: 5, PadChar: ' '},
+			expected: " 0007 and   007",
+		},
+		{
+			name:     "Leading zeros ignored",
+			input:    "0007 and 000",
+			opts:     Options{Width: 2, LeadingZeros: LeadingZerosIgnore},
+			expected: "07 and 00",
+		},
+		{
+			name:     "Leading zeros ignored with space padding",
+			input:    "007",
+			opts:     Options{Width: 3, PadChar: ' ', LeadingZeros: LeadingZerosIgnore},
+			expected: "  7",
+		},
+		{
+			name:     "Truncate past MaxInt64",
+			input:    "12345678901234567890123",
+			opts:     Options{Width: 4, Mode: WidthExact, Overflow: OverflowTruncate},
+			expected: "0123",
+		},
+		{
+			name:     "Zero width",
+			input:    "Test 123",
+			opts:     Options{Width: 0, Mode: WidthExact, Overflow: OverflowTruncate},
+			expected: "Test 123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PadNumbersWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersWithOptions(%q, %+v) unexpected error: %v", tt.input, tt.opts, err)
+			}
+			if result != tt.expected {
+				t.Errorf("PadNumbersWithOptions(%q, %+v) = %q; want %q",
+					tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPadNumbersWithOptionsOverflowError(t *testing.T) {
+	opts := Options{Width: 3, Mode: WidthExact, Overflow: OverflowError}
+
+	if _, err := PadNumbersWithOptions("id 12 and 345", opts); err != nil {
+		t.Errorf("unexpected error for numbers that fit: %v", err)
+	}
+
+	_, err := PadNumbersWithOptions("id 12 and 3456", opts)
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestPadNumbersSigned(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "Negative number",
+			input:    "-7",
+			opts:     Options{Width: 3, Signed: true},
+			expected: "-07",
+		},
+		{
+			name:     "Explicit plus sign",
+			input:    "delta +5",
+			opts:     Options{Width: 3, Signed: true},
+			expected: "delta +05",
+		},
+		{
+			name:     "Sign after punctuation",
+			input:    "t=-4 (-12)",
+			opts:     Options{Width: 3, Signed: true},
+			expected: "t=-04 (-12)",
+		},
+		{
+			name:     "Signed decimal",
+			input:    "-3.14",
+			opts:     Options{Width: 3, Signed: true},
+			expected: "-03.14",
+		},
+		{
+			name:     "Hyphenated date stays separated",
+			input:    "2024-1-5",
+			opts:     Options{Width: 2, Signed: true},
+			expected: "2024-01-05",
+		},
+		{
+			name:     "Hyphen after word",
+			input:    "ID-7 x-5",
+			opts:     Options{Width: 2, Signed: true},
+			expected: "ID-07 x-05",
+		},
+		{
+			name:     "Lone hyphen",
+			input:    "a - b -",
+			opts:     Options{Width: 2, Signed: true},
+			expected: "a - b -",
+		},
+		{
+			name:     "Space padding goes before the sign",
+			input:    "-7",
+			opts:     Options{Width: 4, PadChar: ' ', Signed: true},
+			expected: "  -7",
+		},
+		{
+			name:     "Left alignment",
+			input:    "[-7]",
+			opts:     Options{Width: 4, PadChar: ' ', Align: AlignLeft, Signed: true},
+			expected: "[-7  ]",
+		},
+		{
+			name:     "Exact width truncates magnitude",
+			input:    "-1234",
+			opts:     Options{Width: 3, Signed: true, Mode: WidthExact, Overflow: OverflowTruncate},
+			expected: "-34",
+		},
+		{
+			name:     "Unsigned mode copies the hyphen",
+			input:    "-7",
+			opts:     Options{Width: 3},
+			expected: "-007",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PadNumbersWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersWithOptions(%q, %+v) unexpected error: %v", tt.input, tt.opts, err)
+			}
+			if result != tt.expected {
+				t.Errorf("PadNumbersWithOptions(%q, %+v) = %q; want %q",
+					tt.input, tt.opts, result, tt.expected)
+			}
+		})
+	}
+}
@@ -1,42 +1,334 @@
 package problem1
 
 import (
-	"fmt"
-	"strconv"
-	"strings"
+	"bytes"
+	"unicode"
+	"unicode/utf8"
 )
 
 // PadNumbers takes a string and an integer X, returns a string with whole numbers
 // left-padded with zeros to X characters
 func PadNumbers(input string, width int) string {
-	var result strings.Builder
+	return string(AppendPadNumbers(make([]byte, 0, len(input)+len(input)/4), []byte(input), width))
+}
+
+// AppendPadNumbers appends src with its whole numbers padded to width, as
+// PadNumbers does, to dst and returns the extended buffer. It does not
+// allocate when dst has enough capacity, so a buffer can be reused across
+// calls:
+//
+//	buf = AppendPadNumbers(buf[:0], line, 8)
+func AppendPadNumbers(dst, src []byte, width int) []byte {
+	p := padder{opts: Options{Width: width}}
+	// The default options cannot fail.
+	dst, _, _ = p.pad(dst, src, true)
+	return dst
+}
+
+// UnpadNumbers strips leading zeros from the whole numbers in input, keeping
+// a single "0" for numbers that are all zeros. Fractions after a '.' are left
+// untouched, exactly as in PadNumbers. Padding is undone for any width w >= 0:
+//
+//	UnpadNumbers(PadNumbers(s, w)) == UnpadNumbers(s)
+//
+// so UnpadNumbers(s) is the canonical form of s.
+func UnpadNumbers(input string) string {
+	// Width 0 never pads and never fails.
+	out, _ := PadNumbersWithOptions(input, Options{LeadingZeros: LeadingZerosIgnore})
+	return out
+}
+
+// padder holds the scanning state that has to survive between chunks when
+// the input is not available all at once.
+type padder struct {
+	opts    Options
+	prev    rune  // last input rune consumed, 0 at the start of input
+	prev2   rune  // the rune before prev, 0 if there is none
+	column  int   // whole numbers seen since the last newline
+	columns []int // per-column widths overriding opts.Width, if set
+}
+
+// tokenKind classifies the pieces the input is split into.
+type tokenKind int
+
+const (
+	tokenText     tokenKind = iota // a single character, copied as is
+	tokenWhole                     // a whole number, possibly signed or grouped
+	tokenFraction                  // the digits after a decimal mark
+	tokenLiteral                   // a literal recognised through Options.Literals
+)
+
+type token struct {
+	kind tokenKind
+	text []byte // the token's bytes, without the sign
+	sign byte   // '+' or '-' in front of a whole number, 0 otherwise
+	zero rune   // zero digit of a number's script
+
+	column int // position of a whole number or literal on its line, from 0
+
+	// Whole numbers only, with Options.Precision: the digits after the
+	// decimal mark, nil if there is no fraction
+	frac []byte
+
+	// Literals only
+	class   literalClass
+	fields  [maxFields]span // the numbers in text
+	nfields int
+}
+
+// pad appends the padded form of src to dst and reports how many bytes of
+// src were consumed. Unless final is set, a token that could continue past
+// the end of src (a digit run, a possible sign or grouping mark, a partial
+// UTF-8 sequence) is left unconsumed; the caller must pass it again in front
+// of the next chunk.
+func (p *padder) pad(dst, src []byte, final bool) ([]byte, int, error) {
 	i := 0
+	lineEnd := byte(p.lineEnd())
 
-	for i < len(input) {
-		if i < len(input) && input[i] >= '0' && input[i] <= '9' {
-			start := i
-			for i < len(input) && input[i] >= '0' && input[i] <= '9' {
-				i++
-			}
-			numStr := input[start:i]
-
-			// Check for decimal fraction (dot before number means it's a fractional part)
-			isDecimalPart := start > 0 && input[start-1] == '.'
-
-			if !isDecimalPart {
-				// This is a whole number (possibly with fractional part after it)
-				num, _ := strconv.Atoi(numStr)
-				result.WriteString(fmt.Sprintf("%0*d", width, num))
-			} else {
-				// This is the fractional part of a decimal number - leave as is
-				result.WriteString(numStr)
-			}
-		} else {
-			// Copy character as is
-			result.WriteByte(input[i])
+	for i < len(src) {
+		// Copy plain ASCII text in one go; next would return it one
+		// character at a time with the same effect on p.
+		j := i
+		for j < len(src) && p.plainText(src[j], lineEnd) {
+			j++
+		}
+		if j > i {
+			dst = append(dst, src[i:j]...)
+			p.consumed(src[i:j])
+			i = j
+			continue
+		}
+
+		tok, n, ok := p.next(src[i:], final)
+		if !ok {
+			return dst, i, nil
+		}
+
+		var err error
+		if dst, err = p.appendToken(dst, tok); err != nil {
+			return dst, i, err
+		}
+		i += n
+	}
+
+	return dst, i, nil
+}
+
+// appendToken appends the padded form of tok to dst.
+func (p *padder) appendToken(dst []byte, tok token) ([]byte, error) {
+	if tok.kind != tokenWhole && tok.kind != tokenLiteral {
+		return append(dst, tok.text...), nil
+	}
+
+	opts, width := &p.opts, p.width(tok)
+	if width < 0 {
+		// As with fmt's '-' flag, a negative width aligns left
+		left := p.opts
+		left.Align = AlignLeft
+		opts, width = &left, -width
+	}
+
+	if tok.kind == tokenLiteral {
+		return opts.appendLiteral(dst, width, tok)
+	}
+	if tok.frac != nil {
+		return opts.appendDecimal(dst, width, tok)
+	}
+	return opts.appendNumber(dst, width, tok.sign, tok.text, tok.zero)
+}
+
+// width returns the width a whole number or literal is padded to.
+func (p *padder) width(tok token) int {
+	if tok.column < len(p.columns) {
+		return p.columns[tok.column]
+	}
+	return p.opts.Width
+}
+
+// next splits off the token at the start of src and returns it with its
+// length in bytes. It reports false, without advancing, when the token may
+// continue past the end of src and final is not set.
+func (p *padder) next(src []byte, final bool) (token, int, bool) {
+	i := 0
+
+	var sign byte
+	if p.opts.Signed && isSign(src[0]) && p.signAllowed() {
+		if !final && !utf8.FullRune(src[1:]) {
+			return token{}, 0, false
+		}
+		if startsWithDigit(src[1:]) {
+			sign = src[0]
 			i++
 		}
 	}
 
-	return result.String()
+	if !final && !utf8.FullRune(src[i:]) {
+		return token{}, 0, false
+	}
+	r, size, zero := decodeDigit(src[i:])
+	if zero < 0 {
+		// Copy character as is
+		p.setPrev(r)
+		if r == p.lineEnd() {
+			p.column = 0
+		}
+		return token{kind: tokenText, text: src[:size]}, size, true
+	}
+
+	digitsStart := i
+	if zero == '0' && p.prev != p.opts.Locale.decimal() {
+		tok, end, res := p.literal(src, i, final)
+		switch res {
+		case needMore:
+			return token{}, 0, false
+		case matched:
+			p.consumed(src[:end])
+			tok.sign = sign
+			tok.column = p.column
+			p.column++
+			return tok, end, true
+		}
+	}
+
+	end, _, ok := p.digitRun(src, i, zero, 0, final)
+	if !ok {
+		return token{}, 0, false
+	}
+
+	kind := tokenFraction
+	// Check for decimal fraction (decimal mark before number means it's a fractional part)
+	if p.prev != p.opts.Locale.decimal() {
+		// This is a whole number (possibly with fractional part after it)
+		kind = tokenWhole
+		end, ok = p.scanGroups(src, digitsStart, end, zero, final)
+		if !ok {
+			return token{}, 0, false
+		}
+	}
+	tok := token{kind: kind, text: src[digitsStart:end], sign: sign, zero: zero}
+
+	if kind == tokenWhole && p.opts.Precision > 0 {
+		start, fracEnd, ok := p.fraction(src, end, zero, final)
+		if !ok {
+			return token{}, 0, false
+		}
+		if fracEnd > start {
+			tok.frac = src[start:fracEnd]
+			end = fracEnd
+		}
+	}
+
+	p.consumed(src[:end])
+	if kind == tokenWhole {
+		tok.column = p.column
+		p.column++
+	}
+	return tok, end, true
+}
+
+// setPrev records r as the last rune consumed.
+func (p *padder) setPrev(r rune) {
+	p.prev2, p.prev = p.prev, r
+}
+
+// consumed records the last two runes of b, which was just consumed.
+func (p *padder) consumed(b []byte) {
+	r, size := utf8.DecodeLastRune(b)
+	if size < len(b) {
+		p.prev2, _ = utf8.DecodeLastRune(b[:len(b)-size])
+		p.prev = r
+		return
+	}
+	p.setPrev(r)
+}
+
+// digitRun scans the digits starting at src[i] and returns the end of the run
+// and the number of digits in it. Unless JoinMixedScripts is set, only digits
+// of the script whose zero is given belong to the run. With limit > 0 the
+// scan stops after limit digits. It reports false when the run may continue
+// past the end of src.
+func (p *padder) digitRun(src []byte, i int, zero rune, limit int, final bool) (int, int, bool) {
+	n := 0
+	for limit <= 0 || n < limit {
+		if i == len(src) || (!final && !utf8.FullRune(src[i:])) {
+			return i, n, final
+		}
+		_, size, z := decodeDigit(src[i:])
+		if z < 0 || (z != zero && !p.opts.JoinMixedScripts) {
+			break
+		}
+		i += size
+		n++
+	}
+	return i, n, true
+}
+
+// scanGroups extends the whole number src[start:end] over any following
+// thousands groups ("1,000,000") and returns the new end. A group is only
+// accepted if it has exactly the locale's group size of digits, and the
+// leading group may not be longer than that. It reports false when the
+// answer depends on input beyond the end of src.
+func (p *padder) scanGroups(src []byte, start, end int, zero rune, final bool) (int, bool) {
+	loc := p.opts.Locale
+	size := loc.groupSize()
+	if loc.Grouping == 0 || utf8.RuneCount(src[start:end]) > size {
+		return end, true
+	}
+
+	var buf [utf8.UTFMax]byte
+	mark := buf[:utf8.EncodeRune(buf[:], loc.Grouping)]
+
+	for {
+		rest := src[end:]
+		if !bytes.HasPrefix(rest, mark) {
+			if !final && len(rest) < len(mark) && bytes.HasPrefix(mark, rest) {
+				return 0, false
+			}
+			return end, true
+		}
+
+		k, n, ok := p.digitRun(src, end+len(mark), zero, size+1, final)
+		if !ok {
+			return 0, false
+		}
+		if n != size {
+			return end, true
+		}
+		end = k
+	}
+}
+
+// plainText reports whether c is ASCII text that can never start a token:
+// not a digit, a line end or, for signed numbers, a sign.
+func (p *padder) plainText(c, lineEnd byte) bool {
+	return c < utf8.RuneSelf && (c < '0' || c > '9') && c != lineEnd && !(p.opts.Signed && isSign(c))
+}
+
+// lineEnd returns the rune that ends a line.
+func (p *padder) lineEnd() rune {
+	if p.opts.NullSeparated {
+		return 0
+	}
+	return '\n'
+}
+
+// signAllowed reports whether a '+' or '-' following the previous rune can
+// start a signed number. Signs are only recognised at the start of input or
+// after whitespace and punctuation, so that hyphens joining words or numbers
+// ("ID-7", "2024-1-5") remain separators.
+func (p *padder) signAllowed() bool {
+	r := p.prev
+	switch {
+	case r == 0:
+		return true
+	case r == '.', r == '_', r == p.opts.Locale.decimal():
+		return false
+	case unicode.IsLetter(r), unicode.IsDigit(r):
+		return false
+	}
+	return true
+}
+
+func isSign(c byte) bool {
+	return c == '-' || c == '+'
 }
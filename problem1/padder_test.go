@@ -1,6 +1,7 @@
 package problem1
 
 import (
+	"math"
 	"testing"
 )
 
@@ -127,6 +128,12 @@ func TestPadNumbersEdgeCases(t *testing.T) {
 			width:    -1,
 			expected: "Test 123",
 		},
+		{
+			name:     "Negative width beyond input length",
+			input:    "7",
+			width:    -20,
+			expected: "7                   ",
+		},
 		{
 			name:     "Very large width",
 			input:    "Number 5",
@@ -151,6 +158,48 @@ func TestPadNumbersEdgeCases(t *testing.T) {
 			width:    4,
 			expected: "(0123) 0456-7890",
 		},
+		{
+			name:     "Just past MaxInt64",
+			input:    "n=9223372036854775808",
+			width:    20,
+			expected: "n=09223372036854775808",
+		},
+		{
+			name:     "25-digit serial number",
+			input:    "SN 1234567890123456789012345",
+			width:    3,
+			expected: "SN 1234567890123456789012345",
+		},
+		{
+			name:     "Long run padded as text",
+			input:    "99999999999999999999",
+			width:    24,
+			expected: "000099999999999999999999",
+		},
+		{
+			name:     "Leading zeros are kept",
+			input:    "0007",
+			width:    2,
+			expected: "0007",
+		},
+		{
+			name:     "Leading zeros count toward width",
+			input:    "id 007",
+			width:    5,
+			expected: "id 00007",
+		},
+		{
+			name:     "Largest width without digits",
+			input:    "no digits",
+			width:    math.MaxInt,
+			expected: "no digits",
+		},
+		{
+			name:     "Smallest width without digits",
+			input:    "no digits",
+			width:    math.MinInt,
+			expected: "no digits",
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,23 +213,110 @@ func TestPadNumbersEdgeCases(t *testing.T) {
 	}
 }
 
+var benchmarkInputs = []struct {
+	name  string
+	input string
+	width int
+}{
+	{"Short", "Test 123", 3},
+	{"Medium", "The year 2024 has 365 days and 12 months", 4},
+	{"Long", "Numbers: 1 22 333 4444 55555 666666 7777777 88888888 999999999", 10},
+	{"ManySmall", "1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20", 3},
+	{"LogLine", "2024-03-17T09:41:07.123Z host=web-7 pid=4182 req=88 status=200 bytes=5120 took=3.75ms", 6},
+}
+
 func BenchmarkPadNumbers(b *testing.B) {
-	testCases := []struct {
-		name  string
-		input string
-		width int
-	}{
-		{"Short", "Test 123", 3},
-		{"Medium", "The year 2024 has 365 days and 12 months", 4},
-		{"Long", "Numbers: 1 22 333 4444 55555 666666 7777777 88888888 999999999", 10},
-		{"ManySmall", "1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20", 3},
+	for _, tc := range benchmarkInputs {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = PadNumbers(tc.input, tc.width)
+			}
+		})
 	}
+}
 
-	for _, tc := range testCases {
+// BenchmarkAppendPadNumbers reuses one buffer, as a log pipeline would; it
+// should report 0 allocs/op.
+func BenchmarkAppendPadNumbers(b *testing.B) {
+	for _, tc := range benchmarkInputs {
 		b.Run(tc.name, func(b *testing.B) {
+			src := []byte(tc.input)
+			dst := make([]byte, 0, 4*len(src))
+			b.ReportAllocs()
+			b.SetBytes(int64(len(src)))
 			for i := 0; i < b.N; i++ {
-				_ = PadNumbers(tc.input, tc.width)
+				dst = AppendPadNumbers(dst[:0], src, tc.width)
+			}
+		})
+	}
+}
+
+func TestAppendPadNumbers(t *testing.T) {
+	for _, tc := range benchmarkInputs {
+		dst := AppendPadNumbers([]byte("prefix:"), []byte(tc.input), tc.width)
+		if want := "prefix:" + PadNumbers(tc.input, tc.width); string(dst) != want {
+			t.Errorf("AppendPadNumbers(%q, %d) = %q; want %q", tc.input, tc.width, dst, want)
+		}
+
+		dst = make([]byte, 0, 4*len(tc.input))
+		src := []byte(tc.input)
+		allocs := testing.AllocsPerRun(100, func() {
+			dst = AppendPadNumbers(dst[:0], src, tc.width)
+		})
+		if allocs != 0 {
+			t.Errorf("AppendPadNumbers(%q, %d) allocates %v times; want 0", tc.input, tc.width, allocs)
+		}
+	}
+}
+
+func TestUnpadNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Padded number", "James Bond 007", "James Bond 7"},
+		{"Padded decimal", "PI=03.14", "PI=3.14"},
+		{"Fraction zeros kept", "3.007 and 0.50", "3.007 and 0.50"},
+		{"All zeros", "000 and 0", "0 and 0"},
+		{"Consecutive numbers", "000099UR001337", "99UR1337"},
+		{"No numbers", "Hello World!", "Hello World!"},
+		{"Empty string", "", ""},
+		{"Unicode digits", "第００７話", "第７話"},
+		{"Long run", "0009223372036854775808", "9223372036854775808"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnpadNumbers(tt.input)
+			if result != tt.expected {
+				t.Errorf("UnpadNumbers(%q) = %q; want %q", tt.input, result, tt.expected)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func FuzzPadNumbersRoundTrip(f *testing.F) {
+	f.Add("James Bond 7", 3)
+	f.Add("PI=3.14", 2)
+	f.Add("99UR1337", 6)
+	f.Add("007 is agent 007", 4)
+	f.Add("Values: 1.23 and 45.6789", 3)
+	f.Add("Цена: 100 рублей", 4)
+	f.Add("第７話 ٤٢.٠٥", 3)
+	f.Add("1.2.3 .5 5.", 2)
+
+	f.Fuzz(func(t *testing.T, input string, width int) {
+		// Negative widths pad with trailing spaces, which are not undone
+		width = max(width%32, 0)
+
+		canonical := UnpadNumbers(input)
+		if got := UnpadNumbers(PadNumbers(input, width)); got != canonical {
+			t.Errorf("UnpadNumbers(PadNumbers(%q, %d)) = %q; want %q", input, width, got, canonical)
+		}
+		if got := UnpadNumbers(canonical); got != canonical {
+			t.Errorf("UnpadNumbers is not idempotent on %q: %q -> %q", input, canonical, got)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package problem1
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// parallelThreshold is the input size below which PadNumbersParallel pads
+// sequentially. Starting workers and joining their results costs in the order
+// of tens of microseconds, about what padding a couple of KB takes, so this
+// leaves a wide margin. Run BenchmarkPadNumbersParallel with -cpu to find the
+// crossover on a given machine.
+const parallelThreshold = 64 * 1024
+
+// minParallelChunk is the smallest chunk handed to a worker.
+const minParallelChunk = 16 * 1024
+
+// PadNumbersParallel returns the same result as PadNumbers, padding large
+// inputs on up to workers goroutines; workers <= 0 means GOMAXPROCS. The
+// input is split into chunks at positions where a fresh scan gives the same
+// result as a continuous one: after an ASCII character that is neither a
+// digit nor a '.', so a chunk never starts inside a digit run or a fraction.
+// The chunks are padded concurrently and joined in order.
+func PadNumbersParallel(input string, width, workers int) string {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers == 1 || len(input) < parallelThreshold {
+		return PadNumbers(input, width)
+	}
+	// A few chunks per worker even out differences in chunk cost.
+	chunkSize := max(minParallelChunk, len(input)/(workers*4))
+	return padParallel(input, width, workers, chunkSize)
+}
+
+func padParallel(input string, width, workers, chunkSize int) string {
+	src := []byte(input)
+	bounds := splitChunks(src, chunkSize)
+	results := make([][]byte, len(bounds)-1)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, len(results)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				k := int(next.Add(1)) - 1
+				if k >= len(results) {
+					return
+				}
+				chunk := src[bounds[k]:bounds[k+1]]
+				results[k] = AppendPadNumbers(make([]byte, 0, len(chunk)+len(chunk)/4), chunk, width)
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, r := range results {
+		total += len(r)
+	}
+	var b strings.Builder
+	b.Grow(total)
+	for _, r := range results {
+		b.Write(r)
+	}
+	return b.String()
+}
+
+// splitChunks returns the chunk boundaries of src, starting with 0 and ending
+// with len(src). Each chunk is at least size bytes long, except the last, and
+// ends at a safe split point (see PadNumbersParallel).
+func splitChunks(src []byte, size int) []int {
+	bounds := []int{0}
+	for start := 0; ; {
+		k := start + size
+		for k < len(src) && !safeSplit(src, k) {
+			k++
+		}
+		if k >= len(src) {
+			return append(bounds, len(src))
+		}
+		bounds = append(bounds, k)
+		start = k
+	}
+}
+
+// safeSplit reports whether padding src[:k] and src[k:] separately gives the
+// same result as padding src at once.
+func safeSplit(src []byte, k int) bool {
+	c := src[k-1]
+	return c < utf8.RuneSelf && (c < '0' || c > '9') && c != '.'
+}
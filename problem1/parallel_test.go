@@ -0,0 +1,76 @@
+package problem1
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// randomText returns n bytes of text dense in digits, dots and multibyte
+// characters, the cases where a bad split would change the output.
+func randomText(r *rand.Rand, n int) string {
+	pieces := []string{"0", "7", "42", "1337", ".", ". ", "x", " ", "\n", "3.14", "٣", "１２", "é", "-", ","}
+	var b strings.Builder
+	for b.Len() < n {
+		b.WriteString(pieces[r.Intn(len(pieces))])
+	}
+	return b.String()
+}
+
+func TestPadNumbersParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range []int{0, 1, 100, 5000} {
+		input := randomText(r, size)
+		want := PadNumbers(input, 4)
+		for _, chunkSize := range []int{1, 2, 3, 7, 64} {
+			for _, workers := range []int{1, 3, 8} {
+				if got := padParallel(input, 4, workers, chunkSize); got != want {
+					t.Fatalf("size %d, chunk %d, %d workers: output differs from PadNumbers", size, chunkSize, workers)
+				}
+			}
+		}
+	}
+
+	large := randomText(r, 3*parallelThreshold)
+	if got := PadNumbersParallel(large, 6, 0); got != PadNumbers(large, 6) {
+		t.Errorf("PadNumbersParallel on %d bytes differs from PadNumbers", len(large))
+	}
+}
+
+func TestSplitChunks(t *testing.T) {
+	src := []byte("12345.678 abc 9")
+	got := splitChunks(src, 3)
+	want := []int{0, 10, 13, len(src)}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("splitChunks(%q, 3) = %v; want %v", src, got, want)
+	}
+}
+
+// BenchmarkPadNumbersParallel compares sequential and parallel padding over a
+// range of input sizes, to find the crossover where parallelism pays off
+// (see parallelThreshold).
+func BenchmarkPadNumbersParallel(b *testing.B) {
+	workers := runtime.GOMAXPROCS(0)
+	line := "2024-03-17T09:41:07.123Z host=web-7 pid=4182 req=88 status=200 bytes=5120 took=3.75ms\n"
+
+	for _, size := range []int{4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 8 << 20} {
+		input := strings.Repeat(line, size/len(line)+1)[:size]
+
+		b.Run(fmt.Sprintf("Sequential/%dKB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				_ = PadNumbers(input, 6)
+			}
+		})
+		b.Run(fmt.Sprintf("Parallel/%dKB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			chunkSize := max(1024, size/(4*workers))
+			for i := 0; i < b.N; i++ {
+				// Bypass the threshold to measure the crossover
+				_ = padParallel(input, 6, workers, chunkSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package problem1
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// SpanKind classifies a number found by PadNumbersReport.
+type SpanKind int
+
+const (
+	// SpanWhole is a whole number, padded to the width or already wide
+	// enough.
+	SpanWhole SpanKind = iota
+	// SpanFraction is the digits after a decimal mark, which are never
+	// padded.
+	SpanFraction
+	// SpanOverflow is a whole number wider than the width in WidthExact
+	// mode, kept or truncated according to the Overflow policy.
+	SpanOverflow
+	// SpanLiteral is a literal recognised through Options.Literals.
+	SpanLiteral
+)
+
+func (k SpanKind) String() string {
+	switch k {
+	case SpanWhole:
+		return "whole-number"
+	case SpanFraction:
+		return "fraction-skipped"
+	case SpanOverflow:
+		return "overflow"
+	case SpanLiteral:
+		return "literal"
+	}
+	return "unknown"
+}
+
+// Span describes a number in the input of PadNumbersReport and what it
+// became in the output. Offsets and lengths are in bytes; a whole number's
+// span includes its sign and, with Options.Precision, its fraction.
+type Span struct {
+	Kind SpanKind
+
+	Offset int // position in the input
+	Len    int
+
+	NewOffset int // position in the output
+	NewLen    int
+
+	// Changed is set when the output bytes differ from the input bytes.
+	Changed bool
+}
+
+// PadNumbersReport pads input like PadNumbersWithOptions and also returns a
+// span for every number it found, in input order, so that tools can
+// highlight what changed. Text between numbers is copied unchanged, so the
+// gaps between spans have the same length in input and output.
+func PadNumbersReport(input string, opts Options) (string, []Span, error) {
+	p := padder{opts: opts}
+	src := []byte(input)
+	out := make([]byte, 0, len(src))
+	var spans []Span
+
+	for i := 0; i < len(src); {
+		// Everything is available, so next always returns a token.
+		tok, n, _ := p.next(src[i:], true)
+
+		start := len(out)
+		var err error
+		if out, err = p.appendToken(out, tok); err != nil {
+			return "", nil, err
+		}
+		if tok.kind != tokenText {
+			spans = append(spans, Span{
+				Kind:      p.spanKind(tok),
+				Offset:    i,
+				Len:       n,
+				NewOffset: start,
+				NewLen:    len(out) - start,
+				Changed:   !bytes.Equal(src[i:i+n], out[start:]),
+			})
+		}
+		i += n
+	}
+
+	return string(out), spans, nil
+}
+
+// spanKind classifies a token that is not plain text, following the same
+// rules as appendNumber.
+func (p *padder) spanKind(tok token) SpanKind {
+	switch tok.kind {
+	case tokenFraction:
+		return SpanFraction
+	case tokenLiteral:
+		return SpanLiteral
+	}
+
+	width := p.width(tok)
+	if width < 0 {
+		width = -width
+	}
+	if tok.sign != 0 {
+		width--
+	}
+	if p.opts.Mode == WidthExact && width > 0 && utf8.RuneCount(p.opts.significant(tok.text)) > width {
+		return SpanOverflow
+	}
+	return SpanWhole
+}
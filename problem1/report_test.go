@@ -0,0 +1,132 @@
+package problem1
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPadNumbersReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+		spans    []Span
+	}{
+		{
+			name:     "Whole and fraction",
+			input:    "PI=3.14 x 12",
+			opts:     Options{Width: 2},
+			expected: "PI=03.14 x 12",
+			spans: []Span{
+				{Kind: SpanWhole, Offset: 3, Len: 1, NewOffset: 3, NewLen: 2, Changed: true},
+				{Kind: SpanFraction, Offset: 5, Len: 2, NewOffset: 6, NewLen: 2},
+				{Kind: SpanWhole, Offset: 10, Len: 2, NewOffset: 11, NewLen: 2},
+			},
+		},
+		{
+			name:     "Overflow truncated",
+			input:    "1234 -5",
+			opts:     Options{Width: 3, Mode: WidthExact, Overflow: OverflowTruncate, Signed: true},
+			expected: "234 -05",
+			spans: []Span{
+				{Kind: SpanOverflow, Offset: 0, Len: 4, NewOffset: 0, NewLen: 3, Changed: true},
+				{Kind: SpanWhole, Offset: 5, Len: 2, NewOffset: 4, NewLen: 3, Changed: true},
+			},
+		},
+		{
+			name:     "Overflow kept",
+			input:    "é1234",
+			opts:     Options{Width: 3, Mode: WidthExact},
+			expected: "é1234",
+			spans: []Span{
+				{Kind: SpanOverflow, Offset: 2, Len: 4, NewOffset: 2, NewLen: 4},
+			},
+		},
+		{
+			name:     "Wider number in min mode",
+			input:    "1234",
+			opts:     Options{Width: 3},
+			expected: "1234",
+			spans:    []Span{{Kind: SpanWhole, Len: 4, NewLen: 4}},
+		},
+		{
+			name:     "Same length but changed",
+			input:    "07",
+			opts:     Options{Width: 2, PadChar: ' ', LeadingZeros: LeadingZerosIgnore},
+			expected: " 7",
+			spans:    []Span{{Kind: SpanWhole, Len: 2, NewLen: 2, Changed: true}},
+		},
+		{
+			name:     "Literal",
+			input:    "0x1f",
+			opts:     Options{Width: 4, Literals: DefaultLiterals},
+			expected: "0x001f",
+			spans:    []Span{{Kind: SpanLiteral, Len: 4, NewLen: 6, Changed: true}},
+		},
+		{
+			name:     "No numbers",
+			input:    "text",
+			opts:     Options{Width: 4},
+			expected: "text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, spans, err := PadNumbersReport(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersReport(%q) error: %v", tt.input, err)
+			}
+			if out != tt.expected {
+				t.Errorf("PadNumbersReport(%q) = %q; want %q", tt.input, out, tt.expected)
+			}
+			if !reflect.DeepEqual(spans, tt.spans) {
+				t.Errorf("PadNumbersReport(%q) spans = %+v; want %+v", tt.input, spans, tt.spans)
+			}
+		})
+	}
+}
+
+// TestPadNumbersReportConsistent checks that the output matches
+// PadNumbersWithOptions and that the spans account for every change.
+func TestPadNumbersReportConsistent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	opts := Options{Width: 3, Signed: true, Locale: LocaleEN}
+	for range 100 {
+		input := randomText(r, 200)
+		want, _ := PadNumbersWithOptions(input, opts)
+		out, spans, err := PadNumbersReport(input, opts)
+		if err != nil || out != want {
+			t.Fatalf("PadNumbersReport(%q) = %q, %v; want %q", input, out, err, want)
+		}
+
+		// Rebuild the output from the input and the spans
+		var rebuilt []byte
+		prev := 0
+		for _, s := range spans {
+			rebuilt = append(rebuilt, input[prev:s.Offset]...)
+			if len(rebuilt) != s.NewOffset {
+				t.Fatalf("PadNumbersReport(%q): span %+v starts at output offset %d", input, s, len(rebuilt))
+			}
+			rebuilt = append(rebuilt, out[s.NewOffset:s.NewOffset+s.NewLen]...)
+			if s.Changed == (input[s.Offset:s.Offset+s.Len] == out[s.NewOffset:s.NewOffset+s.NewLen]) {
+				t.Fatalf("PadNumbersReport(%q): span %+v has wrong Changed", input, s)
+			}
+			prev = s.Offset + s.Len
+		}
+		rebuilt = append(rebuilt, input[prev:]...)
+		if string(rebuilt) != out {
+			t.Fatalf("PadNumbersReport(%q): spans do not rebuild the output", input)
+		}
+	}
+}
+
+func TestPadNumbersReportOverflowError(t *testing.T) {
+	_, _, err := PadNumbersReport("1234", Options{Width: 3, Mode: WidthExact, Overflow: OverflowError})
+	if !errors.Is(err, ErrOverflow) {
+		t.Errorf("PadNumbersReport error = %v; want ErrOverflow", err)
+	}
+}
@@ -0,0 +1,167 @@
+package problem1
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rule selects numbers to pad and the width to pad them to. All criteria
+// that are set must hold for a number to be selected; a Rule with none set
+// selects every number.
+type Rule struct {
+	// Index selects the n-th number of a line, counting from 1. Zero selects
+	// numbers at any position.
+	Index int
+
+	// Before must match the text of the line in front of the number, and
+	// After the text following it. Anchor them with $ and ^ to look only at
+	// the adjacent text: `E$` selects the 5 in "S1E5 720p".
+	Before, After *regexp.Regexp
+
+	// Match selects the numbers that lie inside submatch Group of any match
+	// of Match in the line. Group 0 is the whole match.
+	Match *regexp.Regexp
+	Group int
+
+	// Width is the width selected numbers are padded to. Zero leaves them
+	// unchanged, which exempts them from Options.Width.
+	Width int
+}
+
+// PadNumbersByRules pads every number of input that a rule selects to the
+// width of that rule; when several rules select a number, the first one wins.
+// Rules are applied line by line. Numbers that no rule selects are padded to
+// opts.Width, so with a zero Width only the selected numbers change. All
+// other options apply as in PadNumbersWithOptions.
+//
+//	rules := []Rule{{Match: regexp.MustCompile(`E(\d+)`), Group: 1, Width: 3}}
+//	PadNumbersByRules("Show S1E5 720p", rules, Options{}) // "Show S1E005 720p"
+func PadNumbersByRules(input string, rules []Rule, opts Options) (string, error) {
+	lineEnd := string((&padder{opts: opts}).lineEnd())
+	out := make([]byte, 0, len(input))
+
+	var widths []int
+	for len(input) > 0 {
+		line := input
+		if k := strings.Index(input, lineEnd); k >= 0 {
+			line = input[:k+len(lineEnd)]
+		}
+		input = input[len(line):]
+
+		widths = ruleWidths(widths[:0], line, rules, opts)
+		p := padder{opts: opts, columns: widths}
+		var err error
+		out, _, err = p.pad(out, []byte(line), true)
+		if err != nil {
+			return "", err
+		}
+	}
+	return string(out), nil
+}
+
+// ruleWidths appends the width of every number on line, in order, to dst.
+func ruleWidths(dst []int, line string, rules []Rule, opts Options) []int {
+	// Submatch spans of every rule's Match, found on first use
+	groups := make([][][]int, len(rules))
+
+	src := []byte(line)
+	p := padder{opts: opts}
+	for i := 0; i < len(src); {
+		tok, n, _ := p.next(src[i:], true)
+		start := i
+		i += n
+		if tok.kind != tokenWhole && tok.kind != tokenLiteral {
+			continue
+		}
+
+		// The number ends before any fraction that Precision attached
+		width := opts.Width
+		digits := start
+		if tok.sign != 0 {
+			digits++
+		}
+		end := digits + len(tok.text)
+		for k := range rules {
+			r := &rules[k]
+			if r.Match != nil && groups[k] == nil {
+				groups[k] = r.Match.FindAllStringSubmatchIndex(line, -1)
+				if groups[k] == nil {
+					groups[k] = [][]int{}
+				}
+			}
+			if r.selects(line, tok.column, start, digits, end, groups[k]) {
+				width = r.Width
+				break
+			}
+		}
+		dst = append(dst, width)
+	}
+	return dst
+}
+
+// selects reports whether r selects the number at line[start:end], whose
+// digits begin at digits, and which is the column-th number of the line.
+// matches holds the submatch spans of r.Match in line.
+func (r *Rule) selects(line string, column, start, digits, end int, matches [][]int) bool {
+	if r.Index > 0 && column+1 != r.Index {
+		return false
+	}
+	if r.Before != nil && !r.Before.MatchString(line[:start]) {
+		return false
+	}
+	if r.After != nil && !r.After.MatchString(line[end:]) {
+		return false
+	}
+	if r.Match == nil {
+		return true
+	}
+	for _, m := range matches {
+		g := 2 * r.Group
+		if g+1 < len(m) && m[g] >= 0 && m[g] <= digits && end <= m[g+1] {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRule parses the compact rule syntax of the command line, WIDTH being
+// the width to pad to:
+//
+//	PATTERN=WIDTH  numbers inside the first capture group of the regular
+//	               expression PATTERN, or inside the whole match if it has
+//	               no group: `E(\d+)=3`
+//	#N=WIDTH       the N-th number of every line: `#2=4`
+//
+// The last '=' separates the width, so PATTERN may contain '='.
+func ParseRule(s string) (Rule, error) {
+	k := strings.LastIndexByte(s, '=')
+	if k <= 0 {
+		return Rule{}, fmt.Errorf("invalid rule %q: want PATTERN=WIDTH or #N=WIDTH", s)
+	}
+	selector, widthArg := s[:k], s[k+1:]
+
+	width, err := strconv.Atoi(widthArg)
+	if err != nil || width < 0 {
+		return Rule{}, fmt.Errorf("invalid rule %q: bad width %q", s, widthArg)
+	}
+
+	if index, ok := strings.CutPrefix(selector, "#"); ok {
+		n, err := strconv.Atoi(index)
+		if err != nil || n < 1 {
+			return Rule{}, fmt.Errorf("invalid rule %q: bad index %q", s, index)
+		}
+		return Rule{Index: n, Width: width}, nil
+	}
+
+	re, err := regexp.Compile(selector)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %w", s, err)
+	}
+	rule := Rule{Match: re, Width: width}
+	if re.NumSubexp() > 0 {
+		rule.Group = 1
+	}
+	return rule, nil
+}
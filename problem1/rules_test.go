@@ -0,0 +1,81 @@
+package problem1
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPadNumbersByRules(t *testing.T) {
+	episode := Rule{Match: regexp.MustCompile(`E(\d+)`), Group: 1, Width: 3}
+
+	tests := []struct {
+		name     string
+		input    string
+		rules    []Rule
+		opts     Options
+		expected string
+	}{
+		{"Capture group", "Show S1E5 720p", []Rule{episode}, Options{}, "Show S1E005 720p"},
+		{"Named group", "Show S1E5 720p",
+			[]Rule{{Match: regexp.MustCompile(`S(?P<season>\d+)`), Group: 1, Width: 2}}, Options{}, "Show S01E5 720p"},
+		{"Whole match", "a1 b2 a3", []Rule{{Match: regexp.MustCompile(`a\d`), Width: 2}}, Options{}, "a01 b2 a03"},
+		{"Index", "1 2 3\n4 5", []Rule{{Index: 2, Width: 3}}, Options{}, "1 002 3\n4 005"},
+		{"Before context", "S1E5", []Rule{{Before: regexp.MustCompile(`E$`), Width: 2}}, Options{}, "S1E05"},
+		{"After context", "5kg 7m", []Rule{{After: regexp.MustCompile(`^m`), Width: 2}}, Options{}, "5kg 07m"},
+		{"Criteria combine", "E1 E2 E3",
+			[]Rule{{Index: 2, Before: regexp.MustCompile(`E$`), Width: 2}}, Options{}, "E1 E02 E3"},
+		{"First rule wins", "E5", []Rule{episode, {Width: 5}}, Options{}, "E005"},
+		{"Default width for the rest", "Show S1E5 720p", []Rule{episode}, Options{Width: 2}, "Show S01E005 720p"},
+		{"Zero width exempts", "Show S1E5 720p",
+			[]Rule{episode, {After: regexp.MustCompile(`^p`), Width: 0}}, Options{Width: 2}, "Show S01E005 720p"},
+		{"No match", "nothing 7", []Rule{episode}, Options{}, "nothing 7"},
+		{"Fractions never padded", "E1.5", []Rule{{Match: regexp.MustCompile(`E([\d.]+)`), Group: 1, Width: 3}}, Options{}, "E001.5"},
+		{"Signed", "t=-5", []Rule{{Before: regexp.MustCompile(`=$`), Width: 3}}, Options{Signed: true}, "t=-05"},
+		{"Null separated", "E1\x00E2", []Rule{{Index: 1, Width: 2}}, Options{NullSeparated: true}, "E01\x00E02"},
+		{"Empty input", "", []Rule{episode}, Options{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PadNumbersByRules(tt.input, tt.rules, tt.opts)
+			if err != nil {
+				t.Fatalf("PadNumbersByRules(%q) error: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("PadNumbersByRules(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		input    string
+		expected string
+	}{
+		{`E(\d+)=3`, "S1E5 720p", "S1E005 720p"},
+		{`\d+p=5`, "720p 1", "00720p 1"},
+		{`#2=4`, "1 2 3", "1 0002 3"},
+		{`x=(\d+)=2`, "x=5 y=6", "x=05 y=6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRule(%q) error: %v", tt.rule, err)
+			}
+			result, _ := PadNumbersByRules(tt.input, []Rule{rule}, Options{})
+			if result != tt.expected {
+				t.Errorf("rule %q on %q = %q; want %q", tt.rule, tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "E5", "=3", `E(\d+)=x`, `E(\d+)=-1`, "#0=3", "#a=3", "E(=3"} {
+		if _, err := ParseRule(bad); err == nil {
+			t.Errorf("ParseRule(%q) succeeded; want error", bad)
+		}
+	}
+}
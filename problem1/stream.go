@@ -0,0 +1,120 @@
+package problem1
+
+import (
+	"io"
+)
+
+// streamChunkSize is the amount of input read from the source per step.
+const streamChunkSize = 32 * 1024
+
+// PadWriter pads numbers in everything written to it and forwards the result
+// to an underlying writer. The output is byte-identical to PadNumbers applied
+// to the concatenation of all writes, regardless of how the input is split.
+// Close must be called to flush a digit run at the very end of the input.
+type PadWriter struct {
+	w       io.Writer
+	p       padder
+	pending []byte // unconsumed tail of the input (an unfinished digit run)
+	out     []byte
+}
+
+// NewPadWriter returns a PadWriter that writes to w, padding numbers to width.
+func NewPadWriter(w io.Writer, width int) *PadWriter {
+	return NewPadWriterWithOptions(w, Options{Width: width})
+}
+
+// NewPadWriterWithOptions returns a PadWriter that writes to w, padding
+// numbers as PadNumbersWithOptions would.
+func NewPadWriterWithOptions(w io.Writer, opts Options) *PadWriter {
+	return &PadWriter{
+		w: w,
+		p: padder{opts: opts},
+	}
+}
+
+// Write pads b and writes the result to the underlying writer. A digit run at
+// the end of b is held back until the next Write or Close.
+func (pw *PadWriter) Write(b []byte) (int, error) {
+	pw.pending = append(pw.pending, b...)
+	if err := pw.flush(false); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close flushes any buffered input. It does not close the underlying writer.
+func (pw *PadWriter) Close() error {
+	return pw.flush(true)
+}
+
+func (pw *PadWriter) flush(final bool) error {
+	out, n, padErr := pw.p.pad(pw.out[:0], pw.pending, final)
+	pw.pending = pw.pending[:copy(pw.pending, pw.pending[n:])]
+	pw.out = out
+
+	if len(out) > 0 {
+		if _, err := pw.w.Write(out); err != nil {
+			return err
+		}
+	}
+	return padErr
+}
+
+// PadReader reads from an underlying reader and returns its content with
+// numbers padded, producing the same bytes as PadNumbers would for the whole
+// input while only holding a chunk (plus any unfinished digit run) in memory.
+type PadReader struct {
+	r       io.Reader
+	p       padder
+	chunk   []byte
+	pending []byte // unconsumed tail of the input (an unfinished digit run)
+	out     []byte
+	off     int
+	err     error
+}
+
+// NewPadReader returns a PadReader that reads from r, padding numbers to width.
+func NewPadReader(r io.Reader, width int) *PadReader {
+	return NewPadReaderWithOptions(r, Options{Width: width})
+}
+
+// NewPadReaderWithOptions returns a PadReader that reads from r, padding
+// numbers as PadNumbersWithOptions would.
+func NewPadReaderWithOptions(r io.Reader, opts Options) *PadReader {
+	return &PadReader{
+		r:     r,
+		p:     padder{opts: opts},
+		chunk: make([]byte, streamChunkSize),
+	}
+}
+
+// Read reads padded output into b.
+func (pr *PadReader) Read(b []byte) (int, error) {
+	for pr.off == len(pr.out) {
+		if pr.err != nil {
+			return 0, pr.err
+		}
+		pr.fill()
+	}
+
+	n := copy(b, pr.out[pr.off:])
+	pr.off += n
+	return n, nil
+}
+
+// fill reads the next chunk from the source and pads as much of it as can be
+// decided without seeing further input.
+func (pr *PadReader) fill() {
+	n, err := pr.r.Read(pr.chunk)
+	pr.pending = append(pr.pending, pr.chunk[:n]...)
+
+	final := err != nil
+	out, consumed, padErr := pr.p.pad(pr.out[:0], pr.pending, final)
+	pr.pending = pr.pending[:copy(pr.pending, pr.pending[consumed:])]
+	pr.out = out
+	pr.off = 0
+	pr.err = err
+	if padErr != nil {
+		pr.err = padErr
+	}
+}
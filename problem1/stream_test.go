@@ -0,0 +1,114 @@
+package problem1
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var streamInputs = []struct {
+	name  string
+	input string
+	width int
+}{
+	{"James Bond example", "James Bond 7", 3},
+	{"PI decimal example", "PI=3.14", 2},
+	{"Mixed alphanumeric", "99UR1337", 6},
+	{"Complex decimal", "Values: 1.23 and 45.6789", 3},
+	{"Unicode characters", "Цена: 100 рублей", 4},
+	{"Trailing number", "Year 2024", 6},
+	{"Only digits", "1234567890", 12},
+	{"Dot at chunk edge", "a.1 b.22 c.333 4.5", 4},
+	{"Empty string", "", 3},
+}
+
+func TestPadReader(t *testing.T) {
+	for _, tt := range streamInputs {
+		t.Run(tt.name, func(t *testing.T) {
+			want := PadNumbers(tt.input, tt.width)
+
+			readers := map[string]io.Reader{
+				"whole":    strings.NewReader(tt.input),
+				"one byte": iotest.OneByteReader(strings.NewReader(tt.input)),
+				"half":     iotest.HalfReader(strings.NewReader(tt.input)),
+			}
+			for kind, r := range readers {
+				got, err := io.ReadAll(NewPadReader(r, tt.width))
+				if err != nil {
+					t.Fatalf("%s: unexpected error: %v", kind, err)
+				}
+				if string(got) != want {
+					t.Errorf("%s: PadReader(%q, %d) = %q; want %q",
+						kind, tt.input, tt.width, got, want)
+				}
+			}
+		})
+	}
+}
+
+// checkSplits writes input to a PadWriter in two parts, for every split
+// point, and compares the output with PadNumbersWithOptions.
+func checkSplits(t *testing.T, input string, opts Options) {
+	t.Helper()
+	want, err := PadNumbersWithOptions(input, opts)
+	if err != nil {
+		t.Fatalf("PadNumbersWithOptions(%q) error: %v", input, err)
+	}
+
+	for split := 0; split <= len(input); split++ {
+		var buf bytes.Buffer
+		pw := NewPadWriterWithOptions(&buf, opts)
+		for _, part := range []string{input[:split], input[split:]} {
+			if _, err := pw.Write([]byte(part)); err != nil {
+				t.Fatalf("split at %d: Write() error: %v", split, err)
+			}
+		}
+		if err := pw.Close(); err != nil {
+			t.Fatalf("split at %d: Close() error: %v", split, err)
+		}
+		if buf.String() != want {
+			t.Errorf("%+v split at %d: got %q; want %q", opts, split, buf.String(), want)
+		}
+	}
+}
+
+func TestPadWriter(t *testing.T) {
+	for _, tt := range streamInputs {
+		t.Run(tt.name, func(t *testing.T) {
+			// Feed every possible two-way split, plus byte-at-a-time writes.
+			checkSplits(t, tt.input, Options{Width: tt.width})
+
+			want := PadNumbers(tt.input, tt.width)
+			var buf bytes.Buffer
+			pw := NewPadWriter(&buf, tt.width)
+			for i := 0; i < len(tt.input); i++ {
+				pw.Write([]byte{tt.input[i]})
+			}
+			pw.Close()
+			if buf.String() != want {
+				t.Errorf("byte writes: PadWriter(%q, %d) = %q; want %q",
+					tt.input, tt.width, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestPadReaderLargeInput(t *testing.T) {
+	line := "row 7 value 3.14159 id 42\n"
+	input := strings.Repeat(line, 5000)
+
+	got, err := io.ReadAll(NewPadReader(strings.NewReader(input), 5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != PadNumbers(input, 5) {
+		t.Error("PadReader output differs from PadNumbers for multi-chunk input")
+	}
+}
+
+func TestPadWriterSignAtChunkBoundary(t *testing.T) {
+	input := "x -7 y ID-3 -42"
+	checkSplits(t, input, Options{Width: 3, Signed: true})
+}
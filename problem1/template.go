@@ -0,0 +1,253 @@
+package problem1
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Template is a date/time layout such as "YYYY-MM-DD hh:mm" whose numeric
+// fields each have their own width and range. It is created by
+// ParseTemplate.
+type Template struct {
+	layout string
+	elems  []templateElem
+}
+
+// fieldKind identifies a field of a layout.
+type fieldKind int
+
+const (
+	fieldYear fieldKind = iota
+	fieldMillisecond
+	fieldShortYear
+	fieldMonth
+	fieldDay
+	fieldHour
+	fieldMinute
+	fieldSecond
+	numFieldKinds
+)
+
+// templateField describes a field of a layout.
+type templateField struct {
+	kind      fieldKind
+	name      string
+	width     int // padded width, also the maximum number of digits
+	minDigits int
+	min, max  int
+	fraction  bool // digits after the second, padded on the right
+}
+
+// templateFields are the fields a layout may contain. Longer names come
+// first so that parsing is greedy.
+var templateFields = [numFieldKinds]templateField{
+	{kind: fieldYear, name: "YYYY", width: 4, minDigits: 4, min: 0, max: 9999},
+	{kind: fieldMillisecond, name: "SSS", width: 3, minDigits: 1, min: 0, max: 999, fraction: true},
+	{kind: fieldShortYear, name: "YY", width: 2, minDigits: 1, min: 0, max: 99},
+	{kind: fieldMonth, name: "MM", width: 2, minDigits: 1, min: 1, max: 12},
+	{kind: fieldDay, name: "DD", width: 2, minDigits: 1, min: 1, max: 31},
+	{kind: fieldHour, name: "hh", width: 2, minDigits: 1, min: 0, max: 23},
+	{kind: fieldMinute, name: "mm", width: 2, minDigits: 1, min: 0, max: 59},
+	{kind: fieldSecond, name: "ss", width: 2, minDigits: 1, min: 0, max: 59},
+}
+
+// templateElem is either a field or literal text.
+type templateElem struct {
+	field *templateField
+	text  []byte
+}
+
+// ParseTemplate parses a layout made of the fields
+//
+//	YYYY  year, exactly 4 digits
+//	YY    year, 00-99
+//	MM    month, 01-12
+//	DD    day, 01-31
+//	hh    hour, 00-23
+//	mm    minute, 00-59
+//	ss    second, 00-59
+//	SSS   millisecond, 000-999, as the fraction of a second: zeros are
+//	      added on the right, so ".5" is ".500"
+//
+// and literal text between them, which must appear in the input as is. Two
+// fields must be separated by text, and the text may not contain digits.
+func ParseTemplate(layout string) (*Template, error) {
+	t := &Template{layout: layout}
+
+	fields := 0
+	rest := layout
+	for len(rest) > 0 {
+		field := layoutField(rest)
+		if field == nil {
+			r, n := utf8.DecodeRuneInString(rest)
+			if digitZero(r) >= 0 {
+				return nil, fmt.Errorf("invalid template %q: digit %q in literal text", layout, r)
+			}
+			if k := len(t.elems) - 1; k >= 0 && t.elems[k].field == nil {
+				t.elems[k].text = append(t.elems[k].text, rest[:n]...)
+			} else {
+				t.elems = append(t.elems, templateElem{text: []byte(rest[:n])})
+			}
+			rest = rest[n:]
+			continue
+		}
+
+		if k := len(t.elems) - 1; k >= 0 && t.elems[k].field != nil {
+			return nil, fmt.Errorf("invalid template %q: %s directly follows %s", layout, field.name, t.elems[k].field.name)
+		}
+		t.elems = append(t.elems, templateElem{field: field})
+		fields++
+		rest = rest[len(field.name):]
+	}
+
+	if fields == 0 {
+		return nil, fmt.Errorf("invalid template %q: no fields", layout)
+	}
+	return t, nil
+}
+
+func layoutField(s string) *templateField {
+	for k := range templateFields {
+		if strings.HasPrefix(s, templateFields[k].name) {
+			return &templateFields[k]
+		}
+	}
+	return nil
+}
+
+// String returns the layout t was parsed from.
+func (t *Template) String() string {
+	return t.layout
+}
+
+// Apply pads the fields of every match of t in input to their widths
+// ("2024-1-5 3:7" -> "2024-01-05 03:07" for "YYYY-MM-DD hh:mm"). Text that
+// does not match the layout, or whose fields are out of range, is passed
+// through unchanged.
+func (t *Template) Apply(input string) string {
+	return ApplyTemplates(input, t)
+}
+
+// ApplyTemplates is like Template.Apply for several layouts. At every
+// position the first template that matches wins, so longer layouts should
+// come first ("YYYY-MM-DD hh:mm" before "YYYY-MM-DD").
+//
+// Input is split into numbers with the same scanner as PadNumbers: a match
+// must start at a whole number, so the digits after a decimal mark are never
+// taken for a field, and numbers outside matches are left alone.
+func ApplyTemplates(input string, templates ...*Template) string {
+	src := []byte(input)
+	out := make([]byte, 0, len(input)+len(input)/4)
+	p := padder{}
+
+	for i := 0; i < len(src); {
+		matched := false
+		for _, t := range templates {
+			var end int
+			if out, end, matched = t.match(out, &p, src, i); matched {
+				i = end
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		_, n, _ := p.next(src[i:], true)
+		out = append(out, src[i:i+n]...)
+		i += n
+	}
+	return string(out)
+}
+
+// match tries to match t at src[i], scanning with p. On success it appends
+// the normalized text to dst, advances p and returns the end of the match.
+// Otherwise dst and p are left as they were.
+func (t *Template) match(dst []byte, p *padder, src []byte, i int) ([]byte, int, bool) {
+	q := *p
+	start := len(dst)
+	fail := func() ([]byte, int, bool) {
+		return dst[:start], 0, false
+	}
+
+	var values [numFieldKinds]int // 0 for fields not in t
+	for k, e := range t.elems {
+		if e.field == nil {
+			if !bytes.HasPrefix(src[i:], e.text) {
+				return fail()
+			}
+			// Literal text holds no digits, so it splits into text tokens
+			// that end exactly where it does.
+			for end := i + len(e.text); i < end; {
+				_, n, _ := q.next(src[i:], true)
+				i += n
+			}
+			dst = append(dst, e.text...)
+			continue
+		}
+
+		if i == len(src) {
+			return fail()
+		}
+		tok, n, _ := q.next(src[i:], true)
+		// The first field must start a number; later ones may follow a '.'
+		// separator and so be scanned as a fraction.
+		if tok.kind != tokenWhole && (k == 0 || tok.kind != tokenFraction) {
+			return fail()
+		}
+		value, digits := digitsValue(tok.text)
+		f := e.field
+		if digits < f.minDigits || digits > f.width || value < f.min || value > f.max {
+			return fail()
+		}
+		if f.fraction {
+			dst = append(dst, tok.text...)
+			dst = appendPadding(dst, tok.zero, f.width-digits)
+		} else {
+			dst, _ = (&Options{}).appendNumber(dst, f.width, 0, tok.text, tok.zero)
+		}
+		values[f.kind] = value
+		i += n
+	}
+
+	if !validDay(values) {
+		return fail()
+	}
+	*p = q
+	return dst, i, true
+}
+
+// digitsValue returns the value of the digits in b, of any script, and how
+// many there are. Values above 9999 are clamped, which is enough to range
+// check any field.
+func digitsValue(b []byte) (int, int) {
+	value, n := 0, 0
+	for len(b) > 0 {
+		r, size, zero := decodeDigit(b)
+		b = b[size:]
+		n++
+		value = min(value*10+int(r-zero), 10000)
+	}
+	return value, n
+}
+
+// validDay checks the day against the month, and the year when there is one.
+// values holds 0 for missing fields, which no day or month can be.
+func validDay(values [numFieldKinds]int) bool {
+	day, month := values[fieldDay], values[fieldMonth]
+	if day == 0 || month == 0 {
+		return true
+	}
+
+	days := [...]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}[month-1]
+	if year := values[fieldYear]; year > 0 && month == 2 && !isLeap(year) {
+		days = 28
+	}
+	return day <= days
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
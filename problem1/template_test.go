@@ -0,0 +1,71 @@
+package problem1
+
+import "testing"
+
+func TestTemplateApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		layout   string
+		input    string
+		expected string
+	}{
+		{"Date and time", "YYYY-MM-DD hh:mm", "2024-1-5 3:7", "2024-01-05 03:07"},
+		{"Inside text", "YYYY-MM-DD", "from 2024-1-5 to 2024-12-31.", "from 2024-01-05 to 2024-12-31."},
+		{"Other numbers untouched", "hh:mm", "3.5 km at 9:5, 7 left", "3.5 km at 09:05, 7 left"},
+		{"Already padded", "YYYY-MM-DD", "2024-01-05", "2024-01-05"},
+		{"Time with seconds", "hh:mm:ss.SSS", "1:2:3.4", "01:02:03.400"},
+		{"Milliseconds padded on the right", "hh:mm:ss.SSS", "10:20:30.5 10:20:30.05 10:20:30.123", "10:20:30.500 10:20:30.050 10:20:30.123"},
+		{"Dot separators", "DD.MM.YYYY", "5.1.2024", "05.01.2024"},
+		{"Short year", "DD/MM/YY", "5/1/24", "05/01/24"},
+		{"Literal prefix", "[hh:mm]", "[9:5] 9:5", "[09:05] 9:5"},
+		{"Letter separator", "YYYY-MM-DDThh:mm", "2024-1-5T3:7Z", "2024-01-05T03:07Z"},
+		{"Unicode digits", "hh:mm", "٣:٧", "٠٣:٠٧"},
+
+		{"Month out of range", "YYYY-MM-DD", "2024-13-5", "2024-13-5"},
+		{"Hour out of range", "hh:mm", "24:00 23:5", "24:00 23:05"},
+		{"Day past month end", "YYYY-MM-DD", "2024-4-31", "2024-4-31"},
+		{"Leap day", "YYYY-MM-DD", "2024-2-29 2023-2-29", "2024-02-29 2023-2-29"},
+		{"Two-digit YYYY", "YYYY-MM-DD", "24-1-5", "24-1-5"},
+		{"Too many digits", "hh:mm", "003:7", "003:7"},
+		{"Incomplete", "YYYY-MM-DD hh:mm", "2024-1-5", "2024-1-5"},
+		{"Fraction is not a field", "hh:mm", "1.5:7", "1.5:7"},
+		{"After a failed match", "MM-DD", "13-1-5", "13-01-05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.layout)
+			if err != nil {
+				t.Fatalf("ParseTemplate(%q) error: %v", tt.layout, err)
+			}
+			if got := tmpl.Apply(tt.input); got != tt.expected {
+				t.Errorf("%q.Apply(%q) = %q; want %q", tt.layout, tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyTemplates(t *testing.T) {
+	var templates []*Template
+	for _, layout := range []string{"YYYY-MM-DD hh:mm", "YYYY-MM-DD", "hh:mm"} {
+		tmpl, err := ParseTemplate(layout)
+		if err != nil {
+			t.Fatalf("ParseTemplate(%q) error: %v", layout, err)
+		}
+		templates = append(templates, tmpl)
+	}
+
+	input := "2024-1-5 3:7, 2024-2-9 and 6:5"
+	want := "2024-01-05 03:07, 2024-02-09 and 06:05"
+	if got := ApplyTemplates(input, templates...); got != want {
+		t.Errorf("ApplyTemplates(%q) = %q; want %q", input, got, want)
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	for _, layout := range []string{"", "date", "YYYYMMDD", "hh:mm+1", "hhmm"} {
+		if _, err := ParseTemplate(layout); err == nil {
+			t.Errorf("ParseTemplate(%q) succeeded; want error", layout)
+		}
+	}
+}
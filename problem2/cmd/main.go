@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"math/big"
 	"os"
 	"strings"
+	"time"
 
 	"deficheck/problem2/internal/types"
 	"deficheck/problem2/internal/quote"
@@ -19,10 +22,12 @@ func main() {
 		tokenAddress = flag.String("token", "", "Token contract address")
 		quantity     = flag.String("qty", "", "Quantity to trade")
 		side         = flag.String("side", "", "Trade side: buy or sell")
-		rpcURL       = flag.String("rpc", "https://api.mainnet-beta.solana.com", "Solana RPC URL")
+		rpcURL       = flag.String("rpc", "https://api.mainnet-beta.solana.com", "Solana RPC URL, or several separated by commas to fail over between them")
 		mockMode     = flag.Bool("mock", false, "Use mock data instead of real blockchain data")
 		useAPI       = flag.Bool("api", false, "Use Raydium API to find pools dynamically")
 		useOnchain   = flag.Bool("onchain", false, "Fetch all data directly from blockchain (fully onchain)")
+		timeout      = flag.Duration("timeout", 30*time.Second, "Give up on the quote after this long (0 for no limit)")
+		commitment   = flag.String("commitment", "", "Commitment of onchain reads: processed, confirmed or finalized (default: node's choice, finalized)")
 	)
 
 	flag.Usage = func() {
@@ -55,8 +60,16 @@ func main() {
 		log.Fatalf("Invalid side: %s (must be 'buy' or 'sell')", *side)
 	}
 
+	// Validate commitment
+	switch solana.Commitment(*commitment) {
+	case "", solana.CommitmentProcessed, solana.CommitmentConfirmed, solana.CommitmentFinalized:
+	default:
+		log.Fatalf("Invalid commitment: %s (must be 'processed', 'confirmed' or 'finalized')", *commitment)
+	}
+
 	// Create clients
-	solanaClient := solana.NewClient(*rpcURL)
+	solanaClient := solana.NewClient(strings.Split(*rpcURL, ",")...)
+	solanaClient.SetCommitment(solana.Commitment(*commitment))
 	raydiumClient := raydium.NewClient(solanaClient)
 	quoteService := quote.NewService(raydiumClient)
 	
@@ -109,7 +122,17 @@ func main() {
 			Protocol:       types.ProtocolName,
 		}
 	} else {
-		quoteResult, err = quoteService.GetQuote(request)
+		ctx := context.Background()
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
+		}
+
+		quoteResult, err = quoteService.GetQuote(ctx, request)
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Fatalf("Timed out after %s: %v", *timeout, err)
+		}
 		if err != nil {
 			log.Fatalf("Failed to get quote: %v", err)
 		}
@@ -123,5 +146,8 @@ func main() {
 	fmt.Printf("Quantity: %s\n", *quantity)
 	fmt.Printf("Price: %s SOL\n", quoteResult.PriceFormatted)
 	fmt.Printf("Decimals: %d\n", quoteResult.Decimals)
+	if quoteResult.Slot != 0 {
+		fmt.Printf("Slot: %d\n", quoteResult.Slot)
+	}
 	fmt.Println("=======================")
 }
@@ -1,6 +1,7 @@
 package quote
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
@@ -36,12 +37,12 @@ func (s *Service) SetUseOnchain(useOnchain bool) {
 	s.useOnchain = useOnchain
 }
 
-func (s *Service) GetQuote(request *types.QuoteRequest) (*types.QuoteResponse, error) {
+func (s *Service) GetQuote(ctx context.Context, request *types.QuoteRequest) (*types.QuoteResponse, error) {
 	if err := validateRequest(request); err != nil {
 		return nil, err
 	}
 
-	pool, err := s.findPool(request.TokenAddress)
+	pool, err := s.findPool(ctx, request.TokenAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find pool: %w", err)
 	}
@@ -61,21 +62,22 @@ func (s *Service) GetQuote(request *types.QuoteRequest) (*types.QuoteResponse, e
 		TokenSymbol:    GetTokenSymbol(request.TokenAddress),
 		Decimals:       decimals,
 		Protocol:       types.ProtocolName,
+		Slot:           pool.Slot,
 	}, nil
 }
 
-func (s *Service) findPool(tokenAddress string) (*types.PoolInfo, error) {
+func (s *Service) findPool(ctx context.Context, tokenAddress string) (*types.PoolInfo, error) {
 	if s.useAPI {
-		return s.findPoolViaAPI(tokenAddress)
+		return s.findPoolViaAPI(ctx, tokenAddress)
 	}
 
-	return s.findPoolHardcoded(tokenAddress)
+	return s.findPoolHardcoded(ctx, tokenAddress)
 }
 
-func (s *Service) findPoolViaAPI(tokenAddress string) (*types.PoolInfo, error) {
+func (s *Service) findPoolViaAPI(ctx context.Context, tokenAddress string) (*types.PoolInfo, error) {
 	fmt.Printf("Searching for pool via Raydium API for token: %s\n", tokenAddress)
 
-	poolV3, err := s.raydiumAPI.FindPoolByTokenV3(tokenAddress)
+	poolV3, err := s.raydiumAPI.FindPoolByTokenV3(ctx, tokenAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find pool via v3 API: %w", err)
 	}
@@ -90,7 +92,7 @@ func (s *Service) findPoolViaAPI(tokenAddress string) (*types.PoolInfo, error) {
 
 	if s.useOnchain {
 		fmt.Printf("Using onchain data for pool %s\n", poolV3.ID)
-		pool, err := s.raydiumClient.GetPoolInfoOnchain(poolV3.ID)
+		pool, err := s.raydiumClient.GetPoolInfoOnchain(ctx, poolV3.ID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get onchain pool info: %w", err)
 		}
@@ -103,7 +105,7 @@ func (s *Service) findPoolViaAPI(tokenAddress string) (*types.PoolInfo, error) {
 	return pool, nil
 }
 
-func (s *Service) findPoolHardcoded(tokenAddress string) (*types.PoolInfo, error) {
+func (s *Service) findPoolHardcoded(ctx context.Context, tokenAddress string) (*types.PoolInfo, error) {
 	knownPools := map[string]string{
 		// USDC-SOL pool (one of the most active)
 		"epjfwdd5aufqssqem2qn1xzybapc8g4weggkzwytdt1v": "58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2",
@@ -126,9 +128,9 @@ func (s *Service) findPoolHardcoded(tokenAddress string) (*types.PoolInfo, error
 	var err error
 	if s.useOnchain {
 		fmt.Printf("Using onchain data for hardcoded pool %s\n", poolAddress)
-		pool, err = s.raydiumClient.GetPoolInfoOnchain(poolAddress)
+		pool, err = s.raydiumClient.GetPoolInfoOnchain(ctx, poolAddress)
 	} else {
-		pool, err = s.raydiumClient.GetPoolInfo(poolAddress)
+		pool, err = s.raydiumClient.GetPoolInfo(ctx, poolAddress)
 	}
 	if err != nil {
 		return nil, err
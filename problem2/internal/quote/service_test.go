@@ -1,6 +1,7 @@
 package quote
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
@@ -25,7 +26,7 @@ func TestServiceWithV3API(t *testing.T) {
 			Side:         "buy",
 		}
 
-		response, err := service.GetQuote(request)
+		response, err := service.GetQuote(context.Background(), request)
 		if err != nil {
 			t.Logf("Could not get quote (API might be unavailable): %v", err)
 			t.Skip("Skipping test - API might be unavailable")
@@ -52,7 +53,7 @@ func TestV3APIPoolSelection(t *testing.T) {
 
 	t.Run("Verify SOL pool selection logic", func(t *testing.T) {
 		// Search for USDC pool
-		pool, err := apiClient.FindPoolByTokenV3("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+		pool, err := apiClient.FindPoolByTokenV3(context.Background(), "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
 		if err != nil {
 			t.Skip("API unavailable")
 		}
@@ -16,6 +16,7 @@ type QuoteResponse struct {
 	TokenSymbol    string
 	Decimals       int
 	Protocol       string
+	Slot           uint64 // slot of the pool snapshot, 0 if not read onchain
 }
 
 type PoolInfo struct {
@@ -26,6 +27,9 @@ type PoolInfo struct {
 	QuoteReserve  *big.Int
 	BaseDecimals  int
 	QuoteDecimals int
+	// Slot is the slot the pool and its reserves were read at, 0 if they
+	// did not come from the chain.
+	Slot uint64
 }
 
 type TokenInfo struct {
@@ -1,6 +1,7 @@
 package raydium
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -71,10 +72,10 @@ type MarketData struct {
 	RewardApr   []interface{} `json:"rewardApr"`
 }
 
-func (c *APIClient) GetPoolInfoV3(poolID string) (*PoolInfoData, error) {
+func (c *APIClient) GetPoolInfoV3(ctx context.Context, poolID string) (*PoolInfoData, error) {
 	url := fmt.Sprintf("%s/pools/info/ids?ids=%s", apiV3BaseURL, poolID)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch pool info: %w", err)
 	}
@@ -125,13 +126,13 @@ type PoolSearchData struct {
 	TVL         float64   `json:"tvl"`
 }
 
-func (c *APIClient) FindPoolByTokenV3(tokenMint string) (*PoolInfoData, error) {
+func (c *APIClient) FindPoolByTokenV3(ctx context.Context, tokenMint string) (*PoolInfoData, error) {
 	solMint := "So11111111111111111111111111111111111111112"
 
 	url := fmt.Sprintf("%s/pools/info/mint?mint1=%s&poolType=all&poolSortField=liquidity&sortType=desc&pageSize=20&page=1",
 		apiV3BaseURL, tokenMint)
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch pools: %w", err)
 	}
@@ -173,5 +174,19 @@ func (c *APIClient) FindPoolByTokenV3(tokenMint string) (*PoolInfoData, error) {
 		return nil, fmt.Errorf("no pool found for token %s paired with SOL", tokenMint)
 	}
 
-	return c.GetPoolInfoV3(bestPoolID)
+	return c.GetPoolInfoV3(ctx, bestPoolID)
+}
+
+// get sends a GET request bound to ctx. When ctx is canceled or its deadline
+// passes, the error wraps ctx.Err().
+func (c *APIClient) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return resp, err
 }
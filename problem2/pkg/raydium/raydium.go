@@ -1,7 +1,9 @@
 package raydium
 
 import (
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -73,142 +75,234 @@ const (
 	PcMintOffset    = QuoteMintOffset
 )
 
-func (r *Client) GetPoolInfoOnchain(poolAddress string) (*types.PoolInfo, error) {
-	accountInfo, err := r.solanaClient.GetAccountInfo(poolAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get pool account: %w", err)
-	}
+// poolAccount is the part of a Raydium V4 pool account that quotes need.
+type poolAccount struct {
+	baseMint, quoteMint         string
+	baseVault, quoteVault       string
+	baseDecimals, quoteDecimals int
+}
 
-	value, ok := accountInfo["value"].(map[string]interface{})
-	if !ok || value == nil {
+// parsePoolAccount decodes a pool account, nil if it does not exist.
+func parsePoolAccount(account *solana.AccountInfo) (*poolAccount, error) {
+	if account == nil {
 		return nil, fmt.Errorf("pool not found")
 	}
+	data := account.Data
 
-	dataList, ok := value["data"].([]interface{})
-	if !ok || len(dataList) < 2 {
-		return nil, fmt.Errorf("invalid account data format")
+	// Validate data length
+	if len(data) < 600 {
+		return nil, fmt.Errorf("pool data too short: %d bytes (expected at least 600)", len(data))
 	}
 
-	base64Data, ok := dataList[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("invalid data encoding")
+	return &poolAccount{
+		baseDecimals:  int(binary.LittleEndian.Uint64(data[BaseDecimalOffset : BaseDecimalOffset+8])),
+		quoteDecimals: int(binary.LittleEndian.Uint64(data[QuoteDecimalOffset : QuoteDecimalOffset+8])),
+		baseMint:      extractPubkey(data[CoinMintOffset : CoinMintOffset+32]),
+		quoteMint:     extractPubkey(data[PcMintOffset : PcMintOffset+32]),
+		baseVault:     extractPubkey(data[CoinVaultOffset : CoinVaultOffset+32]),
+		quoteVault:    extractPubkey(data[PcVaultOffset : PcVaultOffset+32]),
+	}, nil
+}
+
+func (r *Client) GetPoolInfoOnchain(ctx context.Context, poolAddress string) (*types.PoolInfo, error) {
+	pools, errs, err := r.getPoolInfosOnchain(ctx, []string{poolAddress})
+	if err != nil {
+		return nil, err
+	}
+	if errs[0] != nil {
+		return nil, errs[0]
 	}
+	return pools[0], nil
+}
 
-	data, err := solana.DecodeBase64Data(base64Data)
+// GetPoolInfosOnchain fetches several pools with two batch requests, one for
+// the pool accounts and one for the balances of all their vaults. A pool
+// that cannot be fetched has a nil entry, and the error joins the reasons.
+func (r *Client) GetPoolInfosOnchain(ctx context.Context, poolAddresses []string) ([]*types.PoolInfo, error) {
+	pools, errs, err := r.getPoolInfosOnchain(ctx, poolAddresses)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode account data: %w", err)
+		return nil, err
 	}
 
-	// Validate data length
-	if len(data) < 600 {
-		return nil, fmt.Errorf("pool data too short: %d bytes (expected at least 600)", len(data))
+	var failed []error
+	for k, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("pool %s: %w", poolAddresses[k], err))
+		}
 	}
+	return pools, errors.Join(failed...)
+}
 
-	baseDecimals := int(binary.LittleEndian.Uint64(data[BaseDecimalOffset : BaseDecimalOffset+8]))
-	quoteDecimals := int(binary.LittleEndian.Uint64(data[QuoteDecimalOffset : QuoteDecimalOffset+8]))
+// snapshotAttempts is how many times a pool is read together with its
+// vaults before giving up on getting them all from the same slot.
+const snapshotAttempts = 3
 
-	baseMint := extractPubkey(data[CoinMintOffset : CoinMintOffset+32])
-	quoteMint := extractPubkey(data[PcMintOffset : PcMintOffset+32])
-	baseVault := extractPubkey(data[CoinVaultOffset : CoinVaultOffset+32])
-	quoteVault := extractPubkey(data[PcVaultOffset : PcVaultOffset+32])
+// slotMismatchError reports a pool and its vaults read at different slots.
+type slotMismatchError struct {
+	pool, base, quote uint64
+}
+
+func (e *slotMismatchError) Error() string {
+	return fmt.Sprintf("pool read at slot %d but vaults at slots %d and %d", e.pool, e.base, e.quote)
+}
 
-	baseBalance, err := r.solanaClient.GetTokenAccountBalance(baseVault)
+// getPoolInfosOnchain returns the pools and the error of each pool, or an
+// error if the batch requests failed as a whole.
+//
+// The first batch reads the pool accounts to find their vaults. The second
+// reads every pool again along with its vault balances, no earlier than the
+// first, and is repeated for pools whose three reads came from different
+// slots.
+func (r *Client) getPoolInfosOnchain(ctx context.Context, poolAddresses []string) ([]*types.PoolInfo, []error, error) {
+	pools := make([]*types.PoolInfo, len(poolAddresses))
+	errs := make([]error, len(poolAddresses))
+
+	calls := make([]solana.BatchCall, len(poolAddresses))
+	for k, addr := range poolAddresses {
+		calls[k] = r.solanaClient.AccountInfoCall(addr, 0)
+	}
+	results, err := r.solanaClient.Batch(ctx, calls)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get base vault balance: %w", err)
-	}
+		return nil, nil, fmt.Errorf("failed to get pool accounts: %w", err)
+	}
+
+	accounts := make([]*poolAccount, len(poolAddresses))
+	var pending []int
+	var minSlot uint64
+	for k, res := range results {
+		var accountInfo solana.AccountInfoResult
+		if err := res.Decode(&accountInfo); err != nil {
+			errs[k] = fmt.Errorf("failed to get pool account: %w", err)
+			continue
+		}
+		if accounts[k], errs[k] = parsePoolAccount(accountInfo.Value); errs[k] != nil {
+			continue
+		}
+		pending = append(pending, k)
+		minSlot = max(minSlot, accountInfo.Context.Slot)
+	}
+
+	for attempt := 1; len(pending) > 0; attempt++ {
+		calls = calls[:0]
+		for _, k := range pending {
+			calls = append(calls,
+				r.solanaClient.AccountInfoCall(poolAddresses[k], minSlot),
+				r.solanaClient.TokenAccountBalanceCall(accounts[k].baseVault, minSlot),
+				r.solanaClient.TokenAccountBalanceCall(accounts[k].quoteVault, minSlot))
+		}
+		results, err := r.solanaClient.Batch(ctx, calls)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get vault balances: %w", err)
+		}
+
+		next := pending[:0]
+		for n, k := range pending {
+			pools[k], errs[k] = poolSnapshot(poolAddresses[k], results[3*n:3*n+3])
+			var mismatch *slotMismatchError
+			if errors.As(errs[k], &mismatch) && attempt < snapshotAttempts {
+				// Ask for the newest of the slots next time
+				minSlot = max(minSlot, mismatch.pool, mismatch.base, mismatch.quote)
+				next = append(next, k)
+			}
+		}
+		pending = next
+	}
+
+	return pools, errs, nil
+}
 
-	quoteBalance, err := r.solanaClient.GetTokenAccountBalance(quoteVault)
+// poolSnapshot builds a pool from the results of reading its account and
+// the balances of its base and quote vaults, which must share a slot.
+// Vault addresses are fixed when a pool is created, so those read before
+// still apply.
+func poolSnapshot(poolAddress string, results []solana.BatchResult) (*types.PoolInfo, error) {
+	var accountInfo solana.AccountInfoResult
+	if err := results[0].Decode(&accountInfo); err != nil {
+		return nil, fmt.Errorf("failed to get pool account: %w", err)
+	}
+	account, err := parsePoolAccount(accountInfo.Value)
 	if err != nil {
+		return nil, err
+	}
+
+	var base, quote solana.TokenAccountBalanceResult
+	if err := results[1].Decode(&base); err != nil {
+		return nil, fmt.Errorf("failed to get base vault balance: %w", err)
+	}
+	if err := results[2].Decode(&quote); err != nil {
 		return nil, fmt.Errorf("failed to get quote vault balance: %w", err)
 	}
+	slot := accountInfo.Context.Slot
+	if base.Context.Slot != slot || quote.Context.Slot != slot {
+		return nil, &slotMismatchError{pool: slot, base: base.Context.Slot, quote: quote.Context.Slot}
+	}
 
-	// Extract amounts from balances
-	baseReserve, err := extractTokenAmount(baseBalance)
+	baseReserve, err := extractTokenAmount(base.Value)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract base reserve: %w", err)
 	}
-
-	quoteReserve, err := extractTokenAmount(quoteBalance)
+	quoteReserve, err := extractTokenAmount(quote.Value)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract quote reserve: %w", err)
 	}
 
-	poolInfo := &types.PoolInfo{
+	return &types.PoolInfo{
 		PoolAddress:   poolAddress,
-		BaseToken:     baseMint,
-		QuoteToken:    quoteMint,
+		BaseToken:     account.baseMint,
+		QuoteToken:    account.quoteMint,
 		BaseReserve:   baseReserve,
 		QuoteReserve:  quoteReserve,
-		BaseDecimals:  baseDecimals,
-		QuoteDecimals: quoteDecimals,
-	}
-
-	return poolInfo, nil
+		BaseDecimals:  account.baseDecimals,
+		QuoteDecimals: account.quoteDecimals,
+		Slot:          slot,
+	}, nil
 }
 
-// GetPoolInfo retrieves pool information from account data (legacy method)
-func (r *Client) GetPoolInfo(poolAddress string) (*types.PoolInfo, error) {
-	accountInfo, err := r.solanaClient.GetAccountInfo(poolAddress)
+// GetPoolInfo retrieves pool information from account data (legacy method).
+// The pool is read once to find its vaults, then again together with them
+// in a single getMultipleAccounts call, so the result comes from one slot.
+func (r *Client) GetPoolInfo(ctx context.Context, poolAddress string) (*types.PoolInfo, error) {
+	accountInfo, err := r.solanaClient.GetAccountInfo(ctx, poolAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pool account: %w", err)
 	}
 
-	value, ok := accountInfo["value"].(map[string]interface{})
-	if !ok || value == nil {
-		return nil, fmt.Errorf("pool not found")
-	}
-
-	dataList, ok := value["data"].([]interface{})
-	if !ok || len(dataList) < 2 {
-		return nil, fmt.Errorf("invalid account data format")
-	}
-
-	base64Data, ok := dataList[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("invalid data encoding")
-	}
-
-	data, err := solana.DecodeBase64Data(base64Data)
+	account, err := parsePoolAccount(accountInfo.Value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode account data: %w", err)
+		return nil, err
 	}
 
-	// Validate data length
-	if len(data) < 600 {
-		return nil, fmt.Errorf("pool data too short: %d bytes (expected at least 600)", len(data))
+	// Vault addresses are fixed when a pool is created, so those read
+	// above still apply.
+	accounts, err := r.solanaClient.GetMultipleAccounts(ctx, []string{poolAddress, account.baseVault, account.quoteVault})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool and vault accounts: %w", err)
 	}
 
-	baseDecimals := int(binary.LittleEndian.Uint64(data[BaseDecimalOffset : BaseDecimalOffset+8]))
-	quoteDecimals := int(binary.LittleEndian.Uint64(data[QuoteDecimalOffset : QuoteDecimalOffset+8]))
-
-	baseMint := extractPubkey(data[CoinMintOffset : CoinMintOffset+32])
-	quoteMint := extractPubkey(data[PcMintOffset : PcMintOffset+32])
-	baseVault := extractPubkey(data[CoinVaultOffset : CoinVaultOffset+32])
-	quoteVault := extractPubkey(data[PcVaultOffset : PcVaultOffset+32])
-
-	vaults, err := r.solanaClient.GetMultipleAccounts([]string{baseVault, quoteVault})
+	account, err = parsePoolAccount(accounts.Value[0])
 	if err != nil {
-		return nil, fmt.Errorf("failed to get vault accounts: %w", err)
+		return nil, err
 	}
 
-	baseReserve, err := getTokenBalance(vaults[0])
+	baseReserve, err := getTokenBalance(accounts.Value[1])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get base reserve: %w", err)
 	}
 
-	quoteReserve, err := getTokenBalance(vaults[1])
+	quoteReserve, err := getTokenBalance(accounts.Value[2])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get quote reserve: %w", err)
 	}
 
 	poolInfo := &types.PoolInfo{
 		PoolAddress:   poolAddress,
-		BaseToken:     baseMint,
-		QuoteToken:    quoteMint,
+		BaseToken:     account.baseMint,
+		QuoteToken:    account.quoteMint,
 		BaseReserve:   baseReserve,
 		QuoteReserve:  quoteReserve,
-		BaseDecimals:  baseDecimals,
-		QuoteDecimals: quoteDecimals,
+		BaseDecimals:  account.baseDecimals,
+		QuoteDecimals: account.quoteDecimals,
+		Slot:          accounts.Context.Slot,
 	}
 
 	return poolInfo, nil
@@ -261,56 +355,29 @@ func extractPubkey(data []byte) string {
 	return utils.Base58Encode(data)
 }
 
-func getTokenBalance(accountData map[string]interface{}) (*big.Int, error) {
-	if accountData == nil {
+// getTokenBalance reads the amount of a token account, 0 if it does not
+// exist.
+func getTokenBalance(account *solana.AccountInfo) (*big.Int, error) {
+	if account == nil || len(account.Data) < 8 {
 		return big.NewInt(0), nil
 	}
 
-	value, ok := accountData["data"].([]interface{})
-	if !ok || len(value) < 2 {
-		return big.NewInt(0), nil
-	}
-
-	base64Data, ok := value[0].(string)
-	if !ok {
-		return big.NewInt(0), nil
-	}
-
-	data, err := solana.DecodeBase64Data(base64Data)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(data) < 8 {
-		return big.NewInt(0), nil
-	}
-
-	amount := binary.LittleEndian.Uint64(data[0:8])
+	amount := binary.LittleEndian.Uint64(account.Data[0:8])
 	result := new(big.Int)
 	result.SetUint64(amount)
 	return result, nil
 }
 
-// extractTokenAmount extracts token amount from getTokenAccountBalance response
-func extractTokenAmount(balanceData map[string]interface{}) (*big.Int, error) {
-	if balanceData == nil {
-		return nil, fmt.Errorf("balance data is nil")
-	}
-
-	value, ok := balanceData["value"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid balance response format")
-	}
-
-	amountStr, ok := value["amount"].(string)
-	if !ok {
-		return nil, fmt.Errorf("amount field not found or not a string")
+// extractTokenAmount parses the amount of a getTokenAccountBalance result
+func extractTokenAmount(balance solana.TokenAmount) (*big.Int, error) {
+	if balance.Amount == "" {
+		return nil, fmt.Errorf("amount field not found")
 	}
 
 	amount := new(big.Int)
-	_, success := amount.SetString(amountStr, 10)
+	_, success := amount.SetString(balance.Amount, 10)
 	if !success {
-		return nil, fmt.Errorf("failed to parse amount: %s", amountStr)
+		return nil, fmt.Errorf("failed to parse amount: %s", balance.Amount)
 	}
 
 	return amount, nil
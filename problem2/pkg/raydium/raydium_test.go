@@ -1,10 +1,22 @@
 package raydium
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
-	
+	"time"
+
 	"deficheck/problem2/internal/types"
+	"deficheck/problem2/pkg/solana"
+	"deficheck/problem2/pkg/utils"
 )
 
 func TestCalculatePrice(t *testing.T) {
@@ -118,7 +130,7 @@ func TestExtractPubkey(t *testing.T) {
 func TestGetTokenBalance(t *testing.T) {
 	tests := []struct {
 		name        string
-		accountData map[string]interface{}
+		accountData *solana.AccountInfo
 		expected    int64
 		wantErr     bool
 	}{
@@ -130,18 +142,15 @@ func TestGetTokenBalance(t *testing.T) {
 		},
 		{
 			name: "Valid token account",
-			accountData: map[string]interface{}{
-				"data": []interface{}{
-					"AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==", // 1 encoded as uint64 little endian in base64
-					"base64",
-				},
+			accountData: &solana.AccountInfo{
+				Data: append([]byte{1}, make([]byte, 63)...), // 1 encoded as uint64 little endian
 			},
 			expected: 1,
 			wantErr:  false,
 		},
 		{
 			name:        "Empty data",
-			accountData: map[string]interface{}{},
+			accountData: &solana.AccountInfo{},
 			expected:    0,
 			wantErr:     false,
 		},
@@ -159,4 +168,263 @@ func TestGetTokenBalance(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestExtractTokenAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance solana.TokenAmount
+		want    string
+		wantErr bool
+	}{
+		{"Amount", solana.TokenAmount{Amount: "1500000", Decimals: 6}, "1500000", false},
+		{"Beyond uint64", solana.TokenAmount{Amount: "18446744073709551616"}, "18446744073709551616", false},
+		{"Missing", solana.TokenAmount{}, "", true},
+		{"Not a number", solana.TokenAmount{Amount: "1.5"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractTokenAmount(tt.balance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractTokenAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("extractTokenAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPoolInfoContext(t *testing.T) {
+	// An RPC node that never answers
+	release := make(chan struct{})
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer rpc.Close()
+	defer close(release)
+
+	client := NewClient(solana.NewClient(rpc.URL))
+
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetPoolInfo(ctx, "58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GetPoolInfo() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		_, err := client.GetPoolInfoOnchain(ctx, "58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("GetPoolInfoOnchain() error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+// poolData returns a pool account whose vaults and mints are filled with
+// the byte n, so that every pool has its own addresses.
+func poolData(n byte) []byte {
+	data := make([]byte, 752)
+	binary.LittleEndian.PutUint64(data[BaseDecimalOffset:], 6)
+	binary.LittleEndian.PutUint64(data[QuoteDecimalOffset:], 9)
+	copy(data[BaseVaultOffset:], bytes.Repeat([]byte{n}, 32))
+	copy(data[QuoteVaultOffset:], bytes.Repeat([]byte{n + 1}, 32))
+	copy(data[BaseMintOffset:], bytes.Repeat([]byte{n + 2}, 32))
+	copy(data[QuoteMintOffset:], bytes.Repeat([]byte{n + 3}, 32))
+	return data
+}
+
+// poolRPC is a fake RPC node that knows the pools poolA and poolB, built by
+// poolData(10) and poolData(20), and answers only batch requests. Every
+// vault holds 1000 times its fill byte. slot returns the slot each call of
+// the n-th request, counting from 1, is answered at.
+type poolRPC struct {
+	*httptest.Server
+	requests int
+}
+
+func newPoolRPC(t *testing.T, slot func(n int, req solana.RPCRequest) uint64) *poolRPC {
+	t.Helper()
+	pools := map[string][]byte{"poolA": poolData(10), "poolB": poolData(20)}
+	vaults := map[string]int{}
+	for _, n := range []int{10, 11, 20, 21} {
+		vaults[utils.Base58Encode(bytes.Repeat([]byte{byte(n)}, 32))] = n * 1000
+	}
+
+	rpc := &poolRPC{}
+	rpc.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpc.requests++
+		var reqs []solana.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Errorf("request is not a batch: %v", err)
+			return
+		}
+		var resps []solana.RPCResponse
+		for _, req := range reqs {
+			addr := req.Params.([]interface{})[0].(string)
+			value := "null"
+			switch req.Method {
+			case "getAccountInfo":
+				if data, ok := pools[addr]; ok {
+					value = fmt.Sprintf(`{"data":[%q,"base64"]}`, base64.StdEncoding.EncodeToString(data))
+				}
+			case "getTokenAccountBalance":
+				value = fmt.Sprintf(`{"amount":"%d"}`, vaults[addr])
+			}
+			result := fmt.Sprintf(`{"context":{"slot":%d},"value":%s}`, slot(rpc.requests, req), value)
+			resps = append(resps, solana.RPCResponse{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(result)})
+		}
+		json.NewEncoder(w).Encode(resps)
+	}))
+	t.Cleanup(rpc.Close)
+	return rpc
+}
+
+// minContextSlot returns the minimum slot a call asks for.
+func minContextSlot(req solana.RPCRequest) uint64 {
+	params := req.Params.([]interface{})
+	if len(params) < 2 {
+		return 0
+	}
+	slot, _ := params[1].(map[string]interface{})["minContextSlot"].(float64)
+	return uint64(slot)
+}
+
+func TestGetPoolInfosOnchain(t *testing.T) {
+	rpc := newPoolRPC(t, func(int, solana.RPCRequest) uint64 { return 42 })
+	client := NewClient(solana.NewClient(rpc.URL))
+	got, err := client.GetPoolInfosOnchain(context.Background(), []string{"poolA", "missing", "poolB"})
+	if err == nil || got[1] != nil {
+		t.Errorf("missing pool: got %v, error %v; want nil and an error", got[1], err)
+	}
+	if rpc.requests != 2 {
+		t.Errorf("RPC server got %d requests; want 2", rpc.requests)
+	}
+
+	for k, n := range map[int]int64{0: 10, 2: 20} {
+		pool := got[k]
+		if pool == nil {
+			t.Fatalf("pool %d not fetched", k)
+		}
+		if pool.BaseReserve.Int64() != n*1000 || pool.QuoteReserve.Int64() != (n+1)*1000 {
+			t.Errorf("pool %d reserves = %v, %v; want %d, %d", k, pool.BaseReserve, pool.QuoteReserve, n*1000, (n+1)*1000)
+		}
+		if pool.BaseDecimals != 6 || pool.QuoteDecimals != 9 {
+			t.Errorf("pool %d decimals = %d, %d; want 6, 9", k, pool.BaseDecimals, pool.QuoteDecimals)
+		}
+		if pool.Slot != 42 {
+			t.Errorf("pool %d slot = %d; want 42", k, pool.Slot)
+		}
+		if want := utils.Base58Encode(bytes.Repeat([]byte{byte(n + 2)}, 32)); pool.BaseToken != want {
+			t.Errorf("pool %d base token = %s; want %s", k, pool.BaseToken, want)
+		}
+	}
+
+	// A single pool takes the same two requests
+	rpc.requests = 0
+	if _, err := client.GetPoolInfoOnchain(context.Background(), "poolA"); err != nil || rpc.requests != 2 {
+		t.Errorf("GetPoolInfoOnchain() error = %v after %d requests; want nil after 2", err, rpc.requests)
+	}
+}
+
+func TestGetPoolInfoOnchainSlots(t *testing.T) {
+	t.Run("Diverged once", func(t *testing.T) {
+		rpc := newPoolRPC(t, func(n int, req solana.RPCRequest) uint64 {
+			switch n {
+			case 1:
+				return 100
+			case 2:
+				if minContextSlot(req) != 100 {
+					t.Errorf("second read asks for slot %d; want 100", minContextSlot(req))
+				}
+				// The quote vault is read a slot later
+				if addr := req.Params.([]interface{})[0]; addr == utils.Base58Encode(bytes.Repeat([]byte{11}, 32)) {
+					return 102
+				}
+				return 101
+			}
+			return minContextSlot(req)
+		})
+
+		pool, err := NewClient(solana.NewClient(rpc.URL)).GetPoolInfoOnchain(context.Background(), "poolA")
+		if err != nil {
+			t.Fatalf("GetPoolInfoOnchain() error: %v", err)
+		}
+		if pool.Slot != 102 || rpc.requests != 3 {
+			t.Errorf("pool read at slot %d after %d requests; want slot 102 after 3", pool.Slot, rpc.requests)
+		}
+	})
+
+	t.Run("Never consistent", func(t *testing.T) {
+		rpc := newPoolRPC(t, func(n int, req solana.RPCRequest) uint64 {
+			if req.Method == "getTokenAccountBalance" {
+				return uint64(n) + 1
+			}
+			return uint64(n)
+		})
+
+		_, err := NewClient(solana.NewClient(rpc.URL)).GetPoolInfoOnchain(context.Background(), "poolA")
+		var mismatch *slotMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Errorf("GetPoolInfoOnchain() error = %v; want a slot mismatch", err)
+		}
+		if rpc.requests != 1+snapshotAttempts {
+			t.Errorf("RPC server got %d requests; want %d", rpc.requests, 1+snapshotAttempts)
+		}
+	})
+}
+
+func TestGetPoolInfoSlot(t *testing.T) {
+	// The pool account changes between the two reads; only the second is
+	// consistent with the vaults.
+	first, second := poolData(10), poolData(10)
+	binary.LittleEndian.PutUint64(second[BaseDecimalOffset:], 8)
+	vault := make([]byte, 8)
+	binary.LittleEndian.PutUint64(vault, 5000)
+	encode := func(data []byte) string {
+		return fmt.Sprintf(`{"data":[%q,"base64"]}`, base64.StdEncoding.EncodeToString(data))
+	}
+
+	var multiple [][]interface{}
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req solana.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("bad request: %v", err)
+			return
+		}
+		var result string
+		switch req.Method {
+		case "getAccountInfo":
+			result = fmt.Sprintf(`{"context":{"slot":100},"value":%s}`, encode(first))
+		case "getMultipleAccounts":
+			multiple = append(multiple, req.Params.([]interface{})[0].([]interface{}))
+			result = fmt.Sprintf(`{"context":{"slot":101},"value":[%s,%s,%s]}`, encode(second), encode(vault), encode(vault))
+		}
+		json.NewEncoder(w).Encode(solana.RPCResponse{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(result)})
+	}))
+	defer rpc.Close()
+
+	pool, err := NewClient(solana.NewClient(rpc.URL)).GetPoolInfo(context.Background(), "poolA")
+	if err != nil {
+		t.Fatalf("GetPoolInfo() error: %v", err)
+	}
+	if len(multiple) != 1 || len(multiple[0]) != 3 || multiple[0][0] != "poolA" {
+		t.Errorf("getMultipleAccounts called with %v; want the pool and its two vaults once", multiple)
+	}
+	if pool.Slot != 101 || pool.BaseDecimals != 8 {
+		t.Errorf("pool slot = %d, base decimals = %d; want 101, 8", pool.Slot, pool.BaseDecimals)
+	}
+	if pool.BaseReserve.Int64() != 5000 || pool.QuoteReserve.Int64() != 5000 {
+		t.Errorf("pool reserves = %v, %v; want 5000, 5000", pool.BaseReserve, pool.QuoteReserve)
+	}
+}
@@ -0,0 +1,150 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultMaxBatchSize is the number of calls a new Client sends in one HTTP
+// request; public nodes commonly reject larger batches.
+const DefaultMaxBatchSize = 100
+
+// BatchCall is a single JSON-RPC call of a batch.
+type BatchCall struct {
+	Method string
+	Params []interface{}
+}
+
+// BatchResult is the outcome of the BatchCall at the same index: the raw
+// JSON result, or the error the node answered the call with.
+type BatchResult struct {
+	Result json.RawMessage
+	Err    error
+}
+
+// Decode stores the result in v, or returns the error of the call.
+func (r BatchResult) Decode(v any) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if err := json.Unmarshal(r.Result, v); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return nil
+}
+
+// SetMaxBatchSize sets how many calls Batch sends in one HTTP request. Values
+// below 1 restore DefaultMaxBatchSize.
+func (c *Client) SetMaxBatchSize(n int) {
+	if n < 1 {
+		n = DefaultMaxBatchSize
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBatch = n
+}
+
+// Batch sends calls as JSON-RPC batch requests, as many per HTTP request as
+// the maximum batch size allows, and returns a result for every call in the
+// same order. Failed HTTP requests are retried like single ones, and so are
+// the calls that failed with a transient error; the error of any other call
+// is reported in its result. Batch itself fails only when ctx is done or
+// the node could not be reached at all.
+func (c *Client) Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	c.mu.Lock()
+	size := c.maxBatch
+	c.mu.Unlock()
+
+	results := make([]BatchResult, len(calls))
+	for start := 0; start < len(calls); start += size {
+		end := min(start+size, len(calls))
+		if err := c.batch(ctx, calls[start:end], results[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// batch sends calls in one batch request and stores their outcomes in
+// results.
+func (c *Client) batch(ctx context.Context, calls []BatchCall, results []BatchResult) error {
+	pending := make([]int, len(calls))
+	for k := range pending {
+		pending[k] = k
+	}
+	answered := false
+
+	err := c.do(ctx, "batch", func(url string) error {
+		// Fresh IDs on every attempt keep late answers apart
+		reqs := make([]RPCRequest, len(pending))
+		for n, k := range pending {
+			reqs[n] = RPCRequest{JSONRPC: "2.0", Method: calls[k].Method, Params: calls[k].Params, ID: c.nextID()}
+		}
+		body, err := json.Marshal(reqs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+
+		respBody, err := c.send(ctx, url, body)
+		if err != nil {
+			return err
+		}
+		var resps []RPCResponse
+		if err := json.Unmarshal(respBody, &resps); err != nil {
+			// Nodes answer a batch they refuse as a whole with a single error
+			var single RPCResponse
+			if json.Unmarshal(respBody, &single) == nil && single.Error != nil {
+				return single.Error
+			}
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		byID := make(map[int]RPCResponse, len(resps))
+		for _, resp := range resps {
+			byID[resp.ID] = resp
+		}
+
+		// Keep the calls without an answer or with a transient error
+		var retry error
+		next := pending[:0]
+		for n, req := range reqs {
+			k := pending[n]
+			resp, ok := byID[req.ID]
+			switch {
+			case !ok:
+				next = append(next, k)
+				if retry == nil {
+					retry = fmt.Errorf("%w to %s", errNoResponse, req.Method)
+				}
+			case resp.Error != nil:
+				answered = true
+				results[k] = BatchResult{Err: resp.Error}
+				if retryable(resp.Error) {
+					next = append(next, k)
+					if retry == nil {
+						retry = resp.Error
+					}
+				}
+			default:
+				answered = true
+				results[k] = BatchResult{Result: resp.Result}
+			}
+		}
+		pending = next
+		return retry
+	})
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil || !answered {
+		return err
+	}
+
+	// The node answered some calls; the others keep their last error
+	for _, k := range pending {
+		if results[k].Err == nil {
+			results[k].Err = err
+		}
+	}
+	return nil
+}
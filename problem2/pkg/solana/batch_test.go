@@ -0,0 +1,136 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// batchServer answers every call of a batch with answer, in reverse order,
+// leaving out the calls for which answer returns "".
+func batchServer(t *testing.T, answer func(req RPCRequest) string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var reqs []RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`))
+			return
+		}
+		var resps []json.RawMessage
+		for _, req := range slices.Backward(reqs) {
+			if a := answer(req); a != "" {
+				resps = append(resps, json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0",%s,"id":%d}`, a, req.ID)))
+			}
+		}
+		json.NewEncoder(w).Encode(resps)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// param returns the first parameter of a decoded request.
+func param(req RPCRequest) interface{} {
+	return req.Params.([]interface{})[0]
+}
+
+func echo(req RPCRequest) string {
+	return fmt.Sprintf(`"result":%q`, param(req))
+}
+
+func TestBatch(t *testing.T) {
+	srv, requests := batchServer(t, echo)
+	c := NewClient(srv.URL)
+	c.SetMaxBatchSize(2)
+
+	var calls []BatchCall
+	for k := range 5 {
+		calls = append(calls, BatchCall{Method: "getBalance", Params: []interface{}{fmt.Sprint("account", k)}})
+	}
+	results, err := c.Batch(context.Background(), calls)
+	if err != nil {
+		t.Fatalf("Batch() error: %v", err)
+	}
+	for k, res := range results {
+		if want := fmt.Sprintf(`"account%d"`, k); res.Err != nil || string(res.Result) != want {
+			t.Errorf("result %d = %s, %v; want %s", k, res.Result, res.Err, want)
+		}
+	}
+	if requests.Load() != 3 {
+		t.Errorf("server got %d requests; want 3 for 5 calls in batches of 2", requests.Load())
+	}
+}
+
+func TestBatchCallErrors(t *testing.T) {
+	var behind, silent atomic.Int32
+	srv, requests := batchServer(t, func(req RPCRequest) string {
+		switch param(req) {
+		case "invalid":
+			return `"error":{"code":-32602,"message":"Invalid param"}`
+		case "behind":
+			if behind.Add(1) == 1 {
+				return `"error":{"code":-32005,"message":"Node is behind"}`
+			}
+		case "silent":
+			if silent.Add(1) == 1 {
+				return ""
+			}
+		case "down":
+			return `"error":{"code":-32005,"message":"Node is behind"}`
+		}
+		return echo(req)
+	})
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(testPolicy)
+
+	var calls []BatchCall
+	for _, p := range []string{"ok", "invalid", "behind", "silent", "down"} {
+		calls = append(calls, BatchCall{Method: "getBalance", Params: []interface{}{p}})
+	}
+	results, err := c.Batch(context.Background(), calls)
+	if err != nil {
+		t.Fatalf("Batch() error: %v", err)
+	}
+
+	for _, k := range []int{0, 2, 3} {
+		if results[k].Err != nil {
+			t.Errorf("call %s failed: %v", calls[k].Params[0], results[k].Err)
+		}
+	}
+	var rpcErr *RPCError
+	if !errors.As(results[1].Err, &rpcErr) || rpcErr.Code != -32602 {
+		t.Errorf("invalid call error = %v; want code -32602", results[1].Err)
+	}
+	if !errors.As(results[4].Err, &rpcErr) || rpcErr.Code != -32005 {
+		t.Errorf("down call error = %v; want code -32005", results[4].Err)
+	}
+	// Only "down" keeps being retried after the second request
+	if requests.Load() != 4 {
+		t.Errorf("server got %d requests; want 4", requests.Load())
+	}
+}
+
+func TestBatchRefused(t *testing.T) {
+	srv, _ := rpcServer(t, body(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Batch requests are disabled"},"id":null}`))
+	c := NewClient(srv.URL)
+
+	_, err := c.Batch(context.Background(), []BatchCall{{Method: "getSlot"}})
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32600 {
+		t.Errorf("Batch() error = %v; want code -32600", err)
+	}
+
+	srv, _ = rpcServer(t, status(503))
+	c = NewClient(srv.URL)
+	c.SetRetryPolicy(testPolicy)
+	if _, err := c.Batch(context.Background(), []BatchCall{{Method: "getSlot"}}); err == nil {
+		t.Error("Batch() succeeded against a failing node")
+	}
+}
@@ -2,26 +2,53 @@ package solana
 
 import (
 	"bytes"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type Client struct {
-	rpcURL string
 	client *http.Client
+	retry  RetryPolicy
+	lastID atomic.Int64
+
+	mu             sync.Mutex
+	endpoints      []endpoint // in order of preference
+	maxBatch       int
+	commitment     Commitment
+	minContextSlot uint64
 }
 
-func NewClient(rpcURL string) *Client {
-	if rpcURL == "" {
-		rpcURL = "https://api.mainnet-beta.solana.com"
+// NewClient returns a client for the given RPC endpoints, tried in order:
+// when one fails it is skipped for a while and the next one is used. Blank
+// URLs are ignored; with none, the public mainnet endpoint is used.
+func NewClient(rpcURLs ...string) *Client {
+	c := &Client{
+		client:   &http.Client{},
+		retry:    DefaultRetryPolicy,
+		maxBatch: DefaultMaxBatchSize,
+	}
+	for _, u := range rpcURLs {
+		if u = strings.TrimSpace(u); u != "" {
+			c.endpoints = append(c.endpoints, endpoint{url: u})
+		}
 	}
-	return &Client{
-		rpcURL: rpcURL,
-		client: &http.Client{},
+	if len(c.endpoints) == 0 {
+		c.endpoints = []endpoint{{url: "https://api.mainnet-beta.solana.com"}}
 	}
+	return c
+}
+
+// SetRetryPolicy replaces DefaultRetryPolicy for subsequent requests.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retry = policy
 }
 
 type RPCRequest struct {
@@ -43,84 +70,164 @@ type RPCError struct {
 	Message string `json:"message"`
 }
 
-func (c *Client) GetAccountInfo(address string) (map[string]interface{}, error) {
-	req := RPCRequest{
-		JSONRPC: "2.0",
-		Method:  "getAccountInfo",
-		Params: []interface{}{
-			address,
-			map[string]string{"encoding": "base64"},
-		},
-		ID: 1,
-	}
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error: %s (code: %d)", e.Message, e.Code)
+}
 
-	resp, err := c.doRequest(req)
-	if err != nil {
+// GetAccountInfo returns the account at address; its Value is nil if there
+// is none.
+func (c *Client) GetAccountInfo(ctx context.Context, address string) (*AccountInfoResult, error) {
+	var result AccountInfoResult
+	if err := c.call(ctx, c.AccountInfoCall(address, 0), &result); err != nil {
 		return nil, err
 	}
+	return &result, nil
+}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp.Result, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+// GetMultipleAccounts returns the accounts at addresses, in the same order
+// and all read at the same slot.
+func (c *Client) GetMultipleAccounts(ctx context.Context, addresses []string) (*MultipleAccountsResult, error) {
+	call := BatchCall{
+		Method: "getMultipleAccounts",
+		Params: []interface{}{addresses, c.requestConfig("base64", 0)},
+	}
+	var result MultipleAccountsResult
+	if err := c.call(ctx, call, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Value) != len(addresses) {
+		return nil, fmt.Errorf("got %d accounts for %d addresses", len(result.Value), len(addresses))
 	}
+	return &result, nil
+}
 
-	return result, nil
+// GetTokenAccountBalance returns the balance of the SPL token account at
+// address.
+func (c *Client) GetTokenAccountBalance(ctx context.Context, address string) (*TokenAccountBalanceResult, error) {
+	var result TokenAccountBalanceResult
+	if err := c.call(ctx, c.TokenAccountBalanceCall(address, 0), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
-func (c *Client) GetMultipleAccounts(addresses []string) ([]map[string]interface{}, error) {
-	req := RPCRequest{
-		JSONRPC: "2.0",
-		Method:  "getMultipleAccounts",
-		Params: []interface{}{
-			addresses,
-			map[string]string{"encoding": "base64"},
-		},
-		ID: 1,
+// AccountInfoCall is the getAccountInfo call behind GetAccountInfo, for use
+// in a batch; decode its result into an AccountInfoResult. A non-zero
+// minContextSlot raises the client's minimum for this call.
+func (c *Client) AccountInfoCall(address string, minContextSlot uint64) BatchCall {
+	return BatchCall{
+		Method: "getAccountInfo",
+		Params: []interface{}{address, c.requestConfig("base64", minContextSlot)},
 	}
+}
 
-	resp, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+// TokenAccountBalanceCall is the getTokenAccountBalance call behind
+// GetTokenAccountBalance, for use in a batch; decode its result into a
+// TokenAccountBalanceResult. A non-zero minContextSlot raises the client's
+// minimum for this call.
+func (c *Client) TokenAccountBalanceCall(address string, minContextSlot uint64) BatchCall {
+	params := []interface{}{address}
+	if config := c.requestConfig("", minContextSlot); config != nil {
+		params = append(params, config)
 	}
+	return BatchCall{Method: "getTokenAccountBalance", Params: params}
+}
 
-	var result struct {
-		Value []map[string]interface{} `json:"value"`
+// call sends a single call and decodes its result into v.
+func (c *Client) call(ctx context.Context, call BatchCall, v any) error {
+	resp, err := c.doRequest(ctx, RPCRequest{JSONRPC: "2.0", Method: call.Method, Params: call.Params})
+	if err != nil {
+		return err
 	}
-	if err := json.Unmarshal(resp.Result, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	if err := json.Unmarshal(resp.Result, v); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
 	}
-
-	return result.Value, nil
+	return nil
 }
 
-func (c *Client) GetTokenAccountBalance(address string) (map[string]interface{}, error) {
-	req := RPCRequest{
-		JSONRPC: "2.0",
-		Method:  "getTokenAccountBalance",
-		Params:  []interface{}{address},
-		ID:      1,
+// doRequest sends req under a fresh ID and returns the response, which must
+// carry the same ID. When ctx is canceled or its deadline passes, the error
+// wraps ctx.Err().
+func (c *Client) doRequest(ctx context.Context, req RPCRequest) (*RPCResponse, error) {
+	req.ID = c.nextID()
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.doRequest(req)
+	var resp *RPCResponse
+	err = c.do(ctx, req.Method, func(url string) error {
+		respBody, err := c.send(ctx, url, body)
+		if err != nil {
+			return err
+		}
+		var rpcResp RPCResponse
+		if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		// An error about a request the node could not read has a null ID,
+		// which decodes as 0; request IDs start at 1
+		if rpcResp.ID != req.ID && (rpcResp.Error == nil || rpcResp.ID != 0) {
+			return fmt.Errorf("response ID %d does not match request ID %d", rpcResp.ID, req.ID)
+		}
+		if rpcResp.Error != nil {
+			return rpcResp.Error
+		}
+		resp = &rpcResp
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp.Result, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
-	}
+// do calls attempt with the preferred endpoint until it succeeds. Failures
+// that retryable accepts eject the endpoint and are retried according to the
+// retry policy, moving on to the next endpoint each time; what names the
+// request in errors.
+func (c *Client) do(ctx context.Context, what string, attempt func(url string) error) error {
+	c.mu.Lock()
+	policy := c.retry
+	c.mu.Unlock()
 
-	return result, nil
-}
+	attempts := max(policy.MaxAttempts, 1)
+	for n := 1; ; n++ {
+		k, url, wait := c.pick()
+		if err := sleep(ctx, wait); err != nil {
+			return fmt.Errorf("%s: %w", what, err)
+		}
 
-func (c *Client) doRequest(req RPCRequest) (*RPCResponse, error) {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		err := attempt(url)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s: %w", what, ctx.Err())
+		}
+		if !retryable(err) {
+			return err
+		}
+		c.eject(k, err)
+		if n == attempts {
+			return fmt.Errorf("%s failed after %d attempts: %w", what, n, err)
+		}
+
+		if err := sleep(ctx, policy.backoff(n)); err != nil {
+			return fmt.Errorf("%s: %w", what, err)
+		}
 	}
+}
 
-	httpReq, err := http.NewRequest("POST", c.rpcURL, bytes.NewReader(body))
+// nextID returns a request ID not used before by c.
+func (c *Client) nextID() int {
+	return int(c.lastID.Add(1))
+}
+
+// send makes a single attempt at posting body to url and returns the
+// response body.
+func (c *Client) send(ctx context.Context, url string, body []byte) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -133,23 +240,19 @@ func (c *Client) doRequest(req RPCRequest) (*RPCResponse, error) {
 	}
 	defer httpResp.Body.Close()
 
+	if httpResp.StatusCode != http.StatusOK {
+		start, _ := io.ReadAll(io.LimitReader(httpResp.Body, 512))
+		return nil, &HTTPError{
+			URL:        url,
+			StatusCode: httpResp.StatusCode,
+			Body:       string(start),
+			RetryAfter: parseRetryAfter(httpResp.Header.Get("Retry-After")),
+		}
+	}
+
 	respBody, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
-
-	var rpcResp RPCResponse
-	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error: %s (code: %d)", rpcResp.Error.Message, rpcResp.Error.Code)
-	}
-
-	return &rpcResp, nil
-}
-
-func DecodeBase64Data(data string) ([]byte, error) {
-	return base64.StdEncoding.DecodeString(data)
+	return respBody, nil
 }
@@ -0,0 +1,219 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    5 * time.Millisecond,
+	Ejection:    time.Minute,
+}
+
+const okBody = `{"jsonrpc":"2.0","result":{"value":{"amount":"5"}},"id":1}`
+
+// rpcServer answers with the responses in order, repeating the last one,
+// and counts the requests it gets.
+func rpcServer(t *testing.T, responses ...func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		responses[min(n, len(responses)-1)](w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func status(code int, header ...string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k := 0; k+1 < len(header); k += 2 {
+			w.Header().Set(header[k], header[k+1])
+		}
+		w.WriteHeader(code)
+		w.Write([]byte("<html>busy</html>"))
+	}
+}
+
+// body answers with s, under the ID of the request if s is a response with
+// an ID.
+func body(s string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RPCRequest
+		var resp RPCResponse
+		if json.NewDecoder(r.Body).Decode(&req) == nil && json.Unmarshal([]byte(s), &resp) == nil && resp.ID != 0 {
+			resp.ID = req.ID
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		w.Write([]byte(s))
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []func(w http.ResponseWriter, r *http.Request)
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"Success", []func(http.ResponseWriter, *http.Request){body(okBody)}, 1, false},
+		{"Rate limited then ok", []func(http.ResponseWriter, *http.Request){status(429), status(429), body(okBody)}, 3, false},
+		{"Server error then ok", []func(http.ResponseWriter, *http.Request){status(502), body(okBody)}, 2, false},
+		{"Undecodable body", []func(http.ResponseWriter, *http.Request){body("<html>"), body(okBody)}, 1, true},
+		{"Retryable RPC code", []func(http.ResponseWriter, *http.Request){
+			body(`{"jsonrpc":"2.0","error":{"code":-32005,"message":"Node is behind"},"id":1}`), body(okBody),
+		}, 2, false},
+		{"Permanent RPC code", []func(http.ResponseWriter, *http.Request){
+			body(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param"},"id":1}`),
+		}, 1, true},
+		{"Client error", []func(http.ResponseWriter, *http.Request){status(403)}, 1, true},
+		{"Gives up", []func(http.ResponseWriter, *http.Request){status(503)}, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := rpcServer(t, tt.responses...)
+			c := NewClient(srv.URL)
+			c.SetRetryPolicy(testPolicy)
+
+			_, err := c.GetTokenAccountBalance(context.Background(), "vault")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTokenAccountBalance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("server got %d requests; want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClientErrorTypes(t *testing.T) {
+	srv, _ := rpcServer(t, body(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid param"},"id":1}`))
+	c := NewClient(srv.URL)
+	_, err := c.GetAccountInfo(context.Background(), "x")
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32602 {
+		t.Errorf("error = %v; want RPCError with code -32602", err)
+	}
+
+	srv, _ = rpcServer(t, status(429, "Retry-After", "0"))
+	c = NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 1})
+	_, err = c.GetAccountInfo(context.Background(), "x")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("error = %v; want HTTPError with status 429", err)
+	}
+}
+
+func TestClientResponseID(t *testing.T) {
+	// A proxy hands back the answer to another request
+	srv, _ := rpcServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":{"value":{"amount":"5"}},"id":999}`))
+	})
+	c := NewClient(srv.URL)
+	if _, err := c.GetTokenAccountBalance(context.Background(), "vault"); err == nil {
+		t.Error("GetTokenAccountBalance() accepted a response to another request")
+	}
+}
+
+func TestClientFailover(t *testing.T) {
+	bad, badCalls := rpcServer(t, status(503))
+	good, goodCalls := rpcServer(t, body(okBody))
+
+	c := NewClient(bad.URL, good.URL)
+	c.SetRetryPolicy(testPolicy)
+	for range 3 {
+		if _, err := c.GetTokenAccountBalance(context.Background(), "vault"); err != nil {
+			t.Fatalf("GetTokenAccountBalance() error: %v", err)
+		}
+	}
+
+	// The failing endpoint is ejected after its first failure
+	if badCalls.Load() != 1 || goodCalls.Load() != 3 {
+		t.Errorf("requests: bad %d, good %d; want 1 and 3", badCalls.Load(), goodCalls.Load())
+	}
+}
+
+func TestClientFailoverWithoutRetries(t *testing.T) {
+	bad, badCalls := rpcServer(t, status(503))
+	good, goodCalls := rpcServer(t, body(okBody))
+
+	c := NewClient(bad.URL, good.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 1, Ejection: time.Minute})
+	if _, err := c.GetTokenAccountBalance(context.Background(), "vault"); err == nil {
+		t.Fatal("GetTokenAccountBalance() succeeded against a failing endpoint")
+	}
+	for range 2 {
+		if _, err := c.GetTokenAccountBalance(context.Background(), "vault"); err != nil {
+			t.Fatalf("GetTokenAccountBalance() error: %v", err)
+		}
+	}
+
+	// The single failed attempt still ejects the endpoint
+	if badCalls.Load() != 1 || goodCalls.Load() != 2 {
+		t.Errorf("requests: bad %d, good %d; want 1 and 2", badCalls.Load(), goodCalls.Load())
+	}
+}
+
+func TestClientLocalErrors(t *testing.T) {
+	good, goodCalls := rpcServer(t, body(okBody))
+
+	// A malformed URL is not retried or failed over
+	c := NewClient("http://bad host", good.URL)
+	c.SetRetryPolicy(testPolicy)
+	if _, err := c.GetTokenAccountBalance(context.Background(), "vault"); err == nil {
+		t.Error("GetTokenAccountBalance() succeeded with a malformed URL")
+	}
+	if goodCalls.Load() != 0 {
+		t.Errorf("second endpoint got %d requests; want 0", goodCalls.Load())
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	srv, calls := rpcServer(t, status(429, "Retry-After", "1"), body(okBody))
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(testPolicy)
+
+	start := time.Now()
+	if _, err := c.GetTokenAccountBalance(context.Background(), "vault"); err != nil {
+		t.Fatalf("GetTokenAccountBalance() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v; want at least the 1s Retry-After", elapsed)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server got %d requests; want 2", calls.Load())
+	}
+
+	// The wait is cut short by the context
+	srv, _ = rpcServer(t, status(429, "Retry-After", "60"))
+	c = NewClient(srv.URL)
+	c.SetRetryPolicy(testPolicy)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetTokenAccountBalance(ctx, "vault"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v; want context.DeadlineExceeded", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+		for range 20 {
+			if d := p.backoff(attempt + 1); d < want/2 || d > want {
+				t.Fatalf("backoff(%d) = %v; want between %v and %v", attempt+1, d, want/2, want)
+			}
+		}
+	}
+}
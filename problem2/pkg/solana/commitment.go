@@ -0,0 +1,55 @@
+package solana
+
+// Commitment is how settled the state a node answers from must be.
+type Commitment string
+
+const (
+	// CommitmentProcessed is the node's most recent block, which may still
+	// be skipped by the cluster.
+	CommitmentProcessed Commitment = "processed"
+	// CommitmentConfirmed is a block voted on by a supermajority.
+	CommitmentConfirmed Commitment = "confirmed"
+	// CommitmentFinalized is a block that can no longer be rolled back.
+	CommitmentFinalized Commitment = "finalized"
+)
+
+// SetCommitment sets the commitment sent with every request. The empty
+// Commitment, the default, leaves it to the node, which uses finalized.
+func (c *Client) SetCommitment(commitment Commitment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commitment = commitment
+}
+
+// SetMinContextSlot makes the node refuse requests it cannot answer from
+// the given slot or a later one, so that a client never sees state older
+// than what it saw before. 0, the default, sends no minimum.
+func (c *Client) SetMinContextSlot(slot uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.minContextSlot = slot
+}
+
+// requestConfig returns the configuration object sent with a request, or
+// nil if it would be empty: the client's commitment, the larger of its
+// minimum slot and minContextSlot, and encoding unless that is empty.
+func (c *Client) requestConfig(encoding string, minContextSlot uint64) map[string]interface{} {
+	c.mu.Lock()
+	commitment, slot := c.commitment, max(c.minContextSlot, minContextSlot)
+	c.mu.Unlock()
+
+	config := map[string]interface{}{}
+	if encoding != "" {
+		config["encoding"] = encoding
+	}
+	if commitment != "" {
+		config["commitment"] = commitment
+	}
+	if slot > 0 {
+		config["minContextSlot"] = slot
+	}
+	if len(config) == 0 {
+		return nil
+	}
+	return config
+}
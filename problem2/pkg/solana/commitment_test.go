@@ -0,0 +1,92 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestConfig(t *testing.T) {
+	// The params of every call, batches flattened
+	var params []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []struct {
+			Params json.RawMessage `json:"params"`
+			ID     int             `json:"id"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		batch := body[0] == '['
+		if !batch {
+			body = append(append([]byte{'['}, body...), ']')
+		}
+		json.Unmarshal(body, &reqs)
+
+		var resps []string
+		for _, req := range reqs {
+			params = append(params, string(req.Params))
+			resps = append(resps, fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":5},"value":null},"id":%d}`, req.ID))
+		}
+		if batch {
+			fmt.Fprintf(w, "[%s]", strings.Join(resps, ","))
+		} else {
+			io.WriteString(w, resps[0])
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	c := NewClient(srv.URL)
+	c.GetAccountInfo(ctx, "a")
+	c.GetTokenAccountBalance(ctx, "v")
+	c.SetCommitment(CommitmentConfirmed)
+	c.SetMinContextSlot(100)
+	c.GetAccountInfo(ctx, "a")
+	c.GetTokenAccountBalance(ctx, "v")
+	c.GetMultipleAccounts(ctx, nil)
+	c.Batch(ctx, []BatchCall{c.AccountInfoCall("a", 50), c.TokenAccountBalanceCall("v", 200)})
+
+	want := []string{
+		`["a",{"encoding":"base64"}]`,
+		`["v"]`,
+		`["a",{"commitment":"confirmed","encoding":"base64","minContextSlot":100}]`,
+		`["v",{"commitment":"confirmed","minContextSlot":100}]`,
+		`[null,{"commitment":"confirmed","encoding":"base64","minContextSlot":100}]`,
+		// The larger of the client's and the call's minimum applies
+		`["a",{"commitment":"confirmed","encoding":"base64","minContextSlot":100}]`,
+		`["v",{"commitment":"confirmed","minContextSlot":200}]`,
+	}
+	if len(params) != len(want) {
+		t.Fatalf("server got %d calls; want %d", len(params), len(want))
+	}
+	for k := range want {
+		if params[k] != want[k] {
+			t.Errorf("call %d params = %s; want %s", k, params[k], want[k])
+		}
+	}
+}
+
+func TestSettersWhileInUse(t *testing.T) {
+	// Run with -race: setters may be called while requests are in flight
+	srv, _ := rpcServer(t, body(okBody))
+	c := NewClient(srv.URL)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 20 {
+			c.GetTokenAccountBalance(context.Background(), "vault")
+			c.Batch(context.Background(), []BatchCall{{Method: "getSlot"}})
+		}
+	}()
+	for n := range 20 {
+		c.SetCommitment(CommitmentConfirmed)
+		c.SetMinContextSlot(uint64(n))
+		c.SetMaxBatchSize(n)
+	}
+	<-done
+}
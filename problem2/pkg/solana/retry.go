@@ -0,0 +1,189 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how Client retries failed requests and fails over
+// between endpoints.
+type RetryPolicy struct {
+	// MaxAttempts is the number of tries per request, across all
+	// endpoints. 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles with
+	// every further attempt up to MaxDelay, unless that is 0. Each delay is
+	// randomized between half and all of its value.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Ejection is how long an endpoint that failed is skipped while others
+	// are available. A Retry-After header extends it, and no request is
+	// sent to the endpoint before Retry-After has passed.
+	Ejection time.Duration
+}
+
+// DefaultRetryPolicy is the policy of a new Client.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Ejection:    30 * time.Second,
+}
+
+// HTTPError is returned when an endpoint answers with a status other than
+// 200 OK.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	Body       string        // start of the response body
+	RetryAfter time.Duration // from the Retry-After header, 0 if absent
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d from %s: %s", e.StatusCode, e.URL, e.Body)
+}
+
+// retryableCodes are the JSON-RPC error codes that report a transient
+// condition of the node rather than a bad request.
+var retryableCodes = map[int]bool{
+	-32603: true, // internal error
+	-32004: true, // block not available for slot
+	-32005: true, // node is unhealthy or behind
+	-32014: true, // block status not yet available
+	-32016: true, // minimum context slot has not been reached
+}
+
+// errNoResponse is returned when a node leaves calls of a batch unanswered.
+var errNoResponse = errors.New("no response")
+
+// retryable reports whether a request that failed with err is worth another
+// attempt, possibly on another endpoint: transport failures, 429 and 5xx
+// statuses, transient RPC error codes and unanswered batch calls. Local
+// failures, such as a malformed URL, and responses that cannot be decoded
+// are not.
+func retryable(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return retryableCodes[rpcErr.Code]
+	}
+	if errors.Is(err, errNoResponse) {
+		return true
+	}
+
+	// url.Error is a net.Error itself, so look at the failure it wraps
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// endpoint is an RPC URL and its health.
+type endpoint struct {
+	url          string
+	ejectedUntil time.Time // skipped while others are available
+	retryAt      time.Time // from Retry-After, never used before
+}
+
+// pick returns the index and URL of the first endpoint that is not ejected,
+// or of the one whose ejection ends first if all are, and how long to wait
+// before using it.
+func (c *Client) pick() (int, string, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	best := -1
+	for k, ep := range c.endpoints {
+		if !ep.ejectedUntil.After(now) {
+			best = k
+			break
+		}
+		if best < 0 || ep.ejectedUntil.Before(c.endpoints[best].ejectedUntil) {
+			best = k
+		}
+	}
+	ep := c.endpoints[best]
+	return best, ep.url, max(ep.retryAt.Sub(now), 0)
+}
+
+// eject takes the endpoint out of rotation after it failed with err.
+func (c *Client) eject(k int, err error) {
+	var retryAfter time.Duration
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		retryAfter = httpErr.RetryAfter
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.endpoints[k].ejectedUntil = now.Add(max(c.retry.Ejection, retryAfter))
+	c.endpoints[k].retryAt = now.Add(retryAfter)
+}
+
+// backoff returns the randomized delay after the given failed attempt,
+// counting from 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for range attempt - 1 {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 {
+		d = min(d, p.MaxDelay)
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// parseRetryAfter parses a Retry-After header, given in seconds or as an
+// HTTP date.
+func parseRetryAfter(h string) time.Duration {
+	if h == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(h); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package solana
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Context is the state of the node a result was read from.
+type Context struct {
+	Slot uint64 `json:"slot"`
+}
+
+// AccountInfo is an account as returned by getAccountInfo, with its data
+// already decoded.
+type AccountInfo struct {
+	Lamports   uint64
+	Owner      string
+	Executable bool
+	RentEpoch  uint64
+	Data       []byte
+}
+
+// UnmarshalJSON decodes an account in base64 encoding, whose data is given
+// as [data, "base64"].
+func (a *AccountInfo) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Lamports   uint64   `json:"lamports"`
+		Owner      string   `json:"owner"`
+		Executable bool     `json:"executable"`
+		RentEpoch  uint64   `json:"rentEpoch"`
+		Data       []string `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw.Data) != 2 || raw.Data[1] != "base64" {
+		return fmt.Errorf("invalid account data format")
+	}
+	data, err := base64.StdEncoding.DecodeString(raw.Data[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode account data: %w", err)
+	}
+
+	*a = AccountInfo{
+		Lamports:   raw.Lamports,
+		Owner:      raw.Owner,
+		Executable: raw.Executable,
+		RentEpoch:  raw.RentEpoch,
+		Data:       data,
+	}
+	return nil
+}
+
+// AccountInfoResult is the result of getAccountInfo. Value is nil when the
+// account does not exist.
+type AccountInfoResult struct {
+	Context Context      `json:"context"`
+	Value   *AccountInfo `json:"value"`
+}
+
+// MultipleAccountsResult is the result of getMultipleAccounts, with a nil
+// entry for every account that does not exist.
+type MultipleAccountsResult struct {
+	Context Context        `json:"context"`
+	Value   []*AccountInfo `json:"value"`
+}
+
+// TokenAmount is the balance of an SPL token account. Amount is in base
+// units, as a decimal string because it may exceed 2^53.
+type TokenAmount struct {
+	Amount         string `json:"amount"`
+	Decimals       int    `json:"decimals"`
+	UIAmountString string `json:"uiAmountString"`
+}
+
+// TokenAccountBalanceResult is the result of getTokenAccountBalance.
+type TokenAccountBalanceResult struct {
+	Context Context     `json:"context"`
+	Value   TokenAmount `json:"value"`
+}
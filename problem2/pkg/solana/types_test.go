@@ -0,0 +1,72 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestGetAccountInfo(t *testing.T) {
+	srv, _ := rpcServer(t, body(`{"jsonrpc":"2.0","result":{"context":{"apiVersion":"2.1.0","slot":341197053},"value":{
+		"data":["AQIDBA==","base64"],"executable":false,"lamports":6124800,
+		"owner":"675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8","rentEpoch":18446744073709551615,"space":4}},"id":1}`))
+	c := NewClient(srv.URL)
+
+	got, err := c.GetAccountInfo(context.Background(), "pool")
+	if err != nil {
+		t.Fatalf("GetAccountInfo() error: %v", err)
+	}
+	if got.Context.Slot != 341197053 {
+		t.Errorf("Slot = %d; want 341197053", got.Context.Slot)
+	}
+	a := got.Value
+	if a == nil {
+		t.Fatal("Value = nil; want an account")
+	}
+	if a.Lamports != 6124800 || a.Owner != "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8" || a.Executable || a.RentEpoch != 1<<64-1 {
+		t.Errorf("account = %+v", a)
+	}
+	if !bytes.Equal(a.Data, []byte{1, 2, 3, 4}) {
+		t.Errorf("Data = %v; want [1 2 3 4]", a.Data)
+	}
+}
+
+func TestGetAccountInfoErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"Missing account", `null`, false},
+		{"JSON encoding", `{"data":{"parsed":{}},"lamports":1}`, true},
+		{"Other encoding", `{"data":["AQID","base58"],"lamports":1}`, true},
+		{"Invalid base64", `{"data":["A!","base64"],"lamports":1}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := rpcServer(t, body(`{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":`+tt.value+`},"id":1}`))
+			got, err := NewClient(srv.URL).GetAccountInfo(context.Background(), "x")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAccountInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Value != nil {
+				t.Errorf("Value = %+v; want nil", got.Value)
+			}
+		})
+	}
+}
+
+func TestGetTokenAccountBalance(t *testing.T) {
+	srv, _ := rpcServer(t, body(`{"jsonrpc":"2.0","result":{"context":{"slot":7},"value":{
+		"amount":"18446744073709551616","decimals":6,"uiAmount":1.8446744073709552e13,"uiAmountString":"18446744073709.551616"}},"id":1}`))
+
+	got, err := NewClient(srv.URL).GetTokenAccountBalance(context.Background(), "vault")
+	if err != nil {
+		t.Fatalf("GetTokenAccountBalance() error: %v", err)
+	}
+	want := TokenAmount{Amount: "18446744073709551616", Decimals: 6, UIAmountString: "18446744073709.551616"}
+	if got.Context.Slot != 7 || got.Value != want {
+		t.Errorf("GetTokenAccountBalance() = %+v; want slot 7 and %+v", got, want)
+	}
+}